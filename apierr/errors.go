@@ -52,6 +52,43 @@ const (
 	CodeClntCBFuncIsNil                             // callback function is nil
 	CodeClntCBFuncExec                              // callback function execution error
 	CodeClntDatastoreUnsupported                    // datastore is not supported for this method
+	CodeClntCtxDone                                 // context canceled or deadline exceeded
+	CodeClntFleetNoClients                          // fleet has no clients or client is nil
+	CodeClntFleetConcurrency                        // fleet concurrency must be positive integer
+	CodeClntFleetTargetFailed                       // one or more fleet targets failed
+	CodeClntClabRead                                // can't read containerlab topology file
+	CodeClntClabParse                               // can't parse containerlab topology file
+	CodeClntClabNoSRLNodes                          // no SR Linux nodes found in containerlab topology
+	CodeClntRetryPolicy                             // retry policy is invalid
+	CodeClntBatchEmpty                              // batch has no requests or request is nil
+	CodeClntBatchDuplicateID                        // batch contains requests with duplicate IDs
+	CodeClntBatchNoResp                             // no response found in batch for the request
+	CodeClntResultIndex                             // result index is out of range
+	CodeClntResultDecoding                          // can't decode result into provided type
+	CodeClntBackupWrite                             // can't write configuration backup
+	CodeClntBackupRead                              // can't read configuration backup
+	CodeClntBackupNoConfig                          // configuration backup has no configuration for requested yang models
+	CodeClntDiffTree                                // can't decode configuration tree for diff
+	CodeClntDesiredStateRead                        // can't read desired state
+	CodeClntDesiredStateInvalid                     // desired state is invalid
+	CodeClntCommitNoTimeout                         // confirm timeout must be positive for confirmed commit
+	CodeClntCommitDone                              // confirmed commit is already accepted, rejected or expired
+	CodeClntAuth                                    // authentication provider failed
+	CodeClntAuthNoCert                              // client certificate authentication requires TLS certificate and key
+	CodeClntRecorder                                // can't open recorder file
+	CodeClntReplay                                  // can't load replay file
+	CodeClntReplayNoMatch                           // no recorded exchange matches the request
+	CodeClntHTTPClientIsNil                         // HTTP client or transport is nil
+	CodeClntMiddlewareIsNil                         // middleware is nil
+	CodeClntLoggerIsNil                             // logger is nil
+	CodeClntMetricsIsNil                            // metrics collector is nil
+	CodeClntTracerIsNil                             // tracer is nil
+	CodeClntEndpoint                                // invalid endpoint
+	CodeClntVersionParsing                          // can't parse software version
+	CodeClntUnsupported                             // not supported by the target software release
+	CodeClntCapabilitiesIsNil                       // capabilities registry is nil
+	CodeClntMaxInFlight                             // max in-flight requests must be positive
+	CodeClntTransportConflict                       // transport is set by both WithOptTransport and WithOptReplay
 )
 
 var (
@@ -80,6 +117,43 @@ var (
 	ErrClntCBFuncIsNil          = NewClientError(CodeClntCBFuncIsNil, nil)
 	ErrClntCBFuncExec           = NewClientError(CodeClntCBFuncExec, nil)
 	ErrClntDatastoreUnsupported = NewClientError(CodeClntDatastoreUnsupported, nil)
+	ErrClntCtxDone              = NewClientError(CodeClntCtxDone, nil)
+	ErrClntFleetNoClients       = NewClientError(CodeClntFleetNoClients, nil)
+	ErrClntFleetConcurrency     = NewClientError(CodeClntFleetConcurrency, nil)
+	ErrClntFleetTargetFailed    = NewClientError(CodeClntFleetTargetFailed, nil)
+	ErrClntClabRead             = NewClientError(CodeClntClabRead, nil)
+	ErrClntClabParse            = NewClientError(CodeClntClabParse, nil)
+	ErrClntClabNoSRLNodes       = NewClientError(CodeClntClabNoSRLNodes, nil)
+	ErrClntRetryPolicy          = NewClientError(CodeClntRetryPolicy, nil)
+	ErrClntBatchEmpty           = NewClientError(CodeClntBatchEmpty, nil)
+	ErrClntBatchDuplicateID     = NewClientError(CodeClntBatchDuplicateID, nil)
+	ErrClntBatchNoResp          = NewClientError(CodeClntBatchNoResp, nil)
+	ErrClntResultIndex          = NewClientError(CodeClntResultIndex, nil)
+	ErrClntResultDecoding       = NewClientError(CodeClntResultDecoding, nil)
+	ErrClntBackupWrite          = NewClientError(CodeClntBackupWrite, nil)
+	ErrClntBackupRead           = NewClientError(CodeClntBackupRead, nil)
+	ErrClntBackupNoConfig       = NewClientError(CodeClntBackupNoConfig, nil)
+	ErrClntDiffTree             = NewClientError(CodeClntDiffTree, nil)
+	ErrClntDesiredStateRead     = NewClientError(CodeClntDesiredStateRead, nil)
+	ErrClntDesiredStateInvalid  = NewClientError(CodeClntDesiredStateInvalid, nil)
+	ErrClntCommitNoTimeout      = NewClientError(CodeClntCommitNoTimeout, nil)
+	ErrClntCommitDone           = NewClientError(CodeClntCommitDone, nil)
+	ErrClntAuth                 = NewClientError(CodeClntAuth, nil)
+	ErrClntAuthNoCert           = NewClientError(CodeClntAuthNoCert, nil)
+	ErrClntRecorder             = NewClientError(CodeClntRecorder, nil)
+	ErrClntReplay               = NewClientError(CodeClntReplay, nil)
+	ErrClntReplayNoMatch        = NewClientError(CodeClntReplayNoMatch, nil)
+	ErrClntHTTPClientIsNil      = NewClientError(CodeClntHTTPClientIsNil, nil)
+	ErrClntMiddlewareIsNil      = NewClientError(CodeClntMiddlewareIsNil, nil)
+	ErrClntLoggerIsNil          = NewClientError(CodeClntLoggerIsNil, nil)
+	ErrClntMetricsIsNil         = NewClientError(CodeClntMetricsIsNil, nil)
+	ErrClntTracerIsNil          = NewClientError(CodeClntTracerIsNil, nil)
+	ErrClntEndpoint             = NewClientError(CodeClntEndpoint, nil)
+	ErrClntVersionParsing       = NewClientError(CodeClntVersionParsing, nil)
+	ErrClntUnsupported          = NewClientError(CodeClntUnsupported, nil)
+	ErrClntCapabilitiesIsNil    = NewClientError(CodeClntCapabilitiesIsNil, nil)
+	ErrClntMaxInFlight          = NewClientError(CodeClntMaxInFlight, nil)
+	ErrClntTransportConflict    = NewClientError(CodeClntTransportConflict, nil)
 )
 
 // Error codes for the Message class, which is the main class of the package.
@@ -109,6 +183,7 @@ const (
 	CodeMsgRespMarshalling                                    // JSON response marshalling error
 	CodeMsgReqSettingConfirmTimeout                           // confirm timeout is allowed for SET method only
 	CodeMsgReqSettingDSParams                                 // error setting datastore parameters in request (check underlying error)
+	CodeMsgCmdInvalidPath                                     // invalid command path syntax
 )
 
 var (
@@ -137,6 +212,7 @@ var (
 	ErrMsgRespMarshalling                  = NewMessageError(CodeMsgRespMarshalling, nil)
 	ErrMsgReqSettingConfirmTimeout         = NewMessageError(CodeMsgReqSettingConfirmTimeout, nil)
 	ErrMsgReqSettingDSParams               = NewMessageError(CodeMsgReqSettingDSParams, nil)
+	ErrMsgCmdInvalidPath                   = NewMessageError(CodeMsgCmdInvalidPath, nil)
 )
 
 type ClientError struct {
@@ -156,7 +232,16 @@ func (e ClientError) Error() string {
 		CodeClntIDMismatch, CodeClntJSONRPCResp, CodeClntCmdCreation, CodeClntRPCReqCreation, CodeClntActNONE,
 		CodeClntActUnsupported, CodeClntNoPort, CodeClntNoUsername, CodeClntNoPassword, CodeClntTLSFilesUnspecified,
 		CodeClntTLSFOpenCA, CodeClntTLSLoadCAPEM, CodeClntTLSLoadCertPair, CodeClntTLSCertParsing, CodeClntCBFuncLowerThanCT,
-		CodeClntCBFuncIsNil, CodeClntCBFuncExec, CodeClntDatastoreUnsupported:
+		CodeClntCBFuncIsNil, CodeClntCBFuncExec, CodeClntDatastoreUnsupported, CodeClntCtxDone,
+		CodeClntFleetNoClients, CodeClntFleetConcurrency, CodeClntFleetTargetFailed, CodeClntClabRead,
+		CodeClntClabParse, CodeClntClabNoSRLNodes, CodeClntRetryPolicy, CodeClntBatchEmpty, CodeClntBatchDuplicateID,
+		CodeClntBatchNoResp, CodeClntResultIndex, CodeClntResultDecoding, CodeClntBackupWrite, CodeClntBackupRead,
+		CodeClntBackupNoConfig, CodeClntDiffTree, CodeClntDesiredStateRead, CodeClntDesiredStateInvalid,
+		CodeClntCommitNoTimeout, CodeClntCommitDone, CodeClntAuth, CodeClntAuthNoCert,
+		CodeClntRecorder, CodeClntReplay, CodeClntReplayNoMatch, CodeClntHTTPClientIsNil, CodeClntMiddlewareIsNil,
+		CodeClntLoggerIsNil, CodeClntMetricsIsNil, CodeClntTracerIsNil,
+		CodeClntEndpoint, CodeClntVersionParsing, CodeClntUnsupported,
+		CodeClntCapabilitiesIsNil, CodeClntMaxInFlight, CodeClntTransportConflict:
 		m = e.Code.String()
 	// case CodeClntUndefined:
 	// 	m = "undefined error"
@@ -229,7 +314,10 @@ func (e ClientError) Is(target error) bool {
 }
 
 func (e ClientError) As(target interface{}) bool {
-	return errors.As(e, target)
+	if e.Err == nil {
+		return false
+	}
+	return errors.As(e.Err, target)
 }
 
 func (e ClientError) Unwrap() error {
@@ -253,7 +341,7 @@ func (e MessageError) Error() string {
 		CodeMsgDSCandidateUpdateNoValue, CodeMsgDSToolsSetUpdateOnly, CodeMsgDSToolsCandidateSetOnly,
 		CodeMsgDSCandidateValidateOnly, CodeMsgDSCandidateDiffOnly, CodeMsgDSSpecNotAllowedForUnknownMethod,
 		CodeMsgCLISettingMethod, CodeMsgCLIAddingCmdsInReq, CodeMsgCLISettingOutFormat, CodeMsgCLIMarshalling,
-		CodeMsgRespMarshalling, CodeMsgReqSettingConfirmTimeout, CodeMsgReqSettingDSParams:
+		CodeMsgRespMarshalling, CodeMsgReqSettingConfirmTimeout, CodeMsgReqSettingDSParams, CodeMsgCmdInvalidPath:
 		m = e.Code.String()
 	// case CodeMsgCmdCreation:
 	// 	m = "command creation error"
@@ -322,7 +410,10 @@ func (e MessageError) Is(target error) bool {
 }
 
 func (e MessageError) As(target interface{}) bool {
-	return errors.As(e, target)
+	if e.Err == nil {
+		return false
+	}
+	return errors.As(e.Err, target)
 }
 
 func (e MessageError) Unwrap() error {
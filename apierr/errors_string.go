@@ -34,11 +34,48 @@ func _() {
 	_ = x[CodeClntCBFuncIsNil-23]
 	_ = x[CodeClntCBFuncExec-24]
 	_ = x[CodeClntDatastoreUnsupported-25]
+	_ = x[CodeClntCtxDone-26]
+	_ = x[CodeClntFleetNoClients-27]
+	_ = x[CodeClntFleetConcurrency-28]
+	_ = x[CodeClntFleetTargetFailed-29]
+	_ = x[CodeClntClabRead-30]
+	_ = x[CodeClntClabParse-31]
+	_ = x[CodeClntClabNoSRLNodes-32]
+	_ = x[CodeClntRetryPolicy-33]
+	_ = x[CodeClntBatchEmpty-34]
+	_ = x[CodeClntBatchDuplicateID-35]
+	_ = x[CodeClntBatchNoResp-36]
+	_ = x[CodeClntResultIndex-37]
+	_ = x[CodeClntResultDecoding-38]
+	_ = x[CodeClntBackupWrite-39]
+	_ = x[CodeClntBackupRead-40]
+	_ = x[CodeClntBackupNoConfig-41]
+	_ = x[CodeClntDiffTree-42]
+	_ = x[CodeClntDesiredStateRead-43]
+	_ = x[CodeClntDesiredStateInvalid-44]
+	_ = x[CodeClntCommitNoTimeout-45]
+	_ = x[CodeClntCommitDone-46]
+	_ = x[CodeClntAuth-47]
+	_ = x[CodeClntAuthNoCert-48]
+	_ = x[CodeClntRecorder-49]
+	_ = x[CodeClntReplay-50]
+	_ = x[CodeClntReplayNoMatch-51]
+	_ = x[CodeClntHTTPClientIsNil-52]
+	_ = x[CodeClntMiddlewareIsNil-53]
+	_ = x[CodeClntLoggerIsNil-54]
+	_ = x[CodeClntMetricsIsNil-55]
+	_ = x[CodeClntTracerIsNil-56]
+	_ = x[CodeClntEndpoint-57]
+	_ = x[CodeClntVersionParsing-58]
+	_ = x[CodeClntUnsupported-59]
+	_ = x[CodeClntCapabilitiesIsNil-60]
+	_ = x[CodeClntMaxInFlight-61]
+	_ = x[CodeClntTransportConflict-62]
 }
 
-const _EnumCltErr_name = "undefined errorhost is not set, but mandatorytarget verification errorrequest marshalling errorHTTP request creation errorHTTP send errorHTTP status errorresponse JSON unmarshalling errorrequest and response IDs do not matchJSON-RPC response errorcommand creation errorRPC request creation erroraction can't be NONEunsupported action specifiedport could not be nilusername could not be nilpassword could not be nilone of more files for rootCA / certificate / key are not specifiedfailed to open rootCA filecan't load PEM file for rootCAcan't load PEM file for certificate / key paircertificate parsing errorcallback timeout must be lower than confirm timeoutcallback function is nilcallback function execution errordatastore is not supported for this method"
+const _EnumCltErr_name = "undefined errorhost is not set, but mandatorytarget verification errorrequest marshalling errorHTTP request creation errorHTTP send errorHTTP status errorresponse JSON unmarshalling errorrequest and response IDs do not matchJSON-RPC response errorcommand creation errorRPC request creation erroraction can't be NONEunsupported action specifiedport could not be nilusername could not be nilpassword could not be nilone of more files for rootCA / certificate / key are not specifiedfailed to open rootCA filecan't load PEM file for rootCAcan't load PEM file for certificate / key paircertificate parsing errorcallback timeout must be lower than confirm timeoutcallback function is nilcallback function execution errordatastore is not supported for this methodcontext canceled or deadline exceededfleet has no clients or client is nilfleet concurrency must be positive integerone or more fleet targets failedcan't read containerlab topology filecan't parse containerlab topology fileno SR Linux nodes found in containerlab topologyretry policy is invalidbatch has no requests or request is nilbatch contains requests with duplicate IDsno response found in batch for the requestresult index is out of rangecan't decode result into provided typecan't write configuration backupcan't read configuration backupconfiguration backup has no configuration for requested yang modelscan't decode configuration tree for diffcan't read desired statedesired state is invalidconfirm timeout must be positive for confirmed commitconfirmed commit is already accepted, rejected or expiredauthentication provider failedclient certificate authentication requires TLS certificate and keycan't open recorder filecan't load replay fileno recorded exchange matches the requestHTTP client or transport is nilmiddleware is nillogger is nilmetrics collector is niltracer is nilinvalid endpointcan't parse software versionnot supported by the target software releasecapabilities registry is nilmax in-flight requests must be positivetransport is set by both WithOptTransport and WithOptReplay"
 
-var _EnumCltErr_index = [...]uint16{0, 15, 45, 70, 95, 122, 137, 154, 187, 224, 247, 269, 295, 315, 343, 364, 389, 414, 480, 506, 536, 582, 607, 658, 682, 715, 757}
+var _EnumCltErr_index = [...]uint16{0, 15, 45, 70, 95, 122, 137, 154, 187, 224, 247, 269, 295, 315, 343, 364, 389, 414, 480, 506, 536, 582, 607, 658, 682, 715, 757, 794, 831, 873, 905, 942, 980, 1028, 1051, 1090, 1132, 1174, 1202, 1240, 1272, 1303, 1370, 1410, 1434, 1458, 1511, 1568, 1598, 1664, 1688, 1710, 1750, 1781, 1798, 1811, 1835, 1848, 1864, 1892, 1936, 1964, 2003, 2062}
 
 func (i EnumCltErr) String() string {
 	if i < 0 || i >= EnumCltErr(len(_EnumCltErr_index)-1) {
@@ -75,11 +112,12 @@ func _() {
 	_ = x[CodeMsgRespMarshalling-22]
 	_ = x[CodeMsgReqSettingConfirmTimeout-23]
 	_ = x[CodeMsgReqSettingDSParams-24]
+	_ = x[CodeMsgCmdInvalidPath-25]
 }
 
-const _EnumMsgErr_name = "undefined errorcommand creation errorno delete or replace actions allowed for method set and datastore TOOLSerror setting method in requesterror adding commands in requestmarshalling errorerror setting output format in requesterror getting methodyang models specification on Request.Params level is not supported for methoderror setting yang models specification on Request.Params leveldatastore is not allowed for method getsetting action error for method setvalue isn't specified or not found in the path for method set and datastore CANDIDATEonly update action is allowed with TOOLS datastore for method setonly CANDIDATE and TOOLS datastores allowed for method setonly CANDIDATE datastore allowed for method validateonly CANDIDATE datastore allowed for method diffdatastore specification on Request.Params level is not supported for unknown methoderror setting cli methoderror adding cli commands in requesterror setting output format for cli methodcli request marshalling errorJSON response marshalling errorconfirm timeout is allowed for SET method onlyerror setting datastore parameters in request (check underlying error)"
+const _EnumMsgErr_name = "undefined errorcommand creation errorno delete or replace actions allowed for method set and datastore TOOLSerror setting method in requesterror adding commands in requestmarshalling errorerror setting output format in requesterror getting methodyang models specification on Request.Params level is not supported for methoderror setting yang models specification on Request.Params leveldatastore is not allowed for method getsetting action error for method setvalue isn't specified or not found in the path for method set and datastore CANDIDATEonly update action is allowed with TOOLS datastore for method setonly CANDIDATE and TOOLS datastores allowed for method setonly CANDIDATE datastore allowed for method validateonly CANDIDATE datastore allowed for method diffdatastore specification on Request.Params level is not supported for unknown methoderror setting cli methoderror adding cli commands in requesterror setting output format for cli methodcli request marshalling errorJSON response marshalling errorconfirm timeout is allowed for SET method onlyerror setting datastore parameters in request (check underlying error)invalid command path syntax"
 
-var _EnumMsgErr_index = [...]uint16{0, 15, 37, 108, 139, 171, 188, 226, 246, 323, 386, 425, 460, 545, 610, 668, 720, 768, 851, 875, 911, 953, 982, 1013, 1059, 1129}
+var _EnumMsgErr_index = [...]uint16{0, 15, 37, 108, 139, 171, 188, 226, 246, 323, 386, 425, 460, 545, 610, 668, 720, 768, 851, 875, 911, 953, 982, 1013, 1059, 1129, 1156}
 
 func (i EnumMsgErr) String() string {
 	if i < 0 || i >= EnumMsgErr(len(_EnumMsgErr_index)-1) {
@@ -0,0 +1,99 @@
+package apierr
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Patterns used to find failing path in the error message returned by the server.
+var jsonRPCPathRes = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)path\s+'([^']+)'`),
+	regexp.MustCompile(`(?i)path\s+"([^"]+)"`),
+	regexp.MustCompile(`(?i)error in path:?\s+(\S+)`),
+}
+
+// Patterns used to find YANG validation failure reason following them in the error message returned by the server.
+// Matched on the original message, since lowercasing could change byte offsets of multibyte characters.
+var (
+	jsonRPCValidationRe  = regexp.MustCompile(`(?i)validation failed`)
+	jsonRPCPathInvalidRe = regexp.MustCompile(`(?i)^path not valid`)
+)
+
+// Message fragments identifying commit conflict.
+var jsonRPCConflicts = []string{"already in progress", "another commit", "is locked", "conflict"}
+
+// JSONRPCError type to represent JSON RPC error object returned by the server, wrapped into ClientError with code CodeClntJSONRPCResp.
+//
+//	Code, Message and Data are taken from the error object as is.
+//	Path is the failing path reported by the server, if any.
+//	Validation is the YANG validation failure reason, if error is caused by validation of the path or value.
+//	Conflict reports whether error is caused by commit conflict, e.g. commit confirmed is already in progress or candidate is locked.
+type JSONRPCError struct {
+	Code       int
+	Message    string
+	Data       json.RawMessage
+	Path       string
+	Validation string
+	Conflict   bool
+}
+
+// NewJSONRPCError returns JSONRPCError with details parsed from the message and data.
+func NewJSONRPCError(code int, message string, data json.RawMessage) *JSONRPCError {
+	e := &JSONRPCError{Code: code, Message: message, Data: data}
+	lm := strings.ToLower(message)
+
+	// path: structured data takes precedence over the message
+	var d struct {
+		Path string `json:"path"`
+	}
+	if len(data) != 0 && json.Unmarshal(data, &d) == nil && d.Path != "" {
+		e.Path = d.Path
+	} else {
+		for _, re := range jsonRPCPathRes {
+			if m := re.FindStringSubmatch(message); m != nil {
+				e.Path = m[1]
+				break
+			}
+		}
+	}
+
+	// validation reason
+	vloc := jsonRPCValidationRe.FindStringIndex(message)
+	ploc := jsonRPCPathInvalidRe.FindStringIndex(message)
+	switch {
+	case vloc != nil:
+		e.Validation = strings.TrimLeft(message[vloc[1]:], ": -")
+	case ploc != nil:
+		e.Validation = strings.TrimLeft(message[ploc[1]:], ": -")
+	case strings.Contains(lm, "invalid value"), strings.Contains(lm, "mandatory"), strings.Contains(lm, "must statement"):
+		e.Validation = message
+	}
+
+	// commit conflict
+	for _, c := range jsonRPCConflicts {
+		if strings.Contains(lm, c) {
+			e.Conflict = true
+			break
+		}
+	}
+	return e
+}
+
+func (e *JSONRPCError) Error() string {
+	if len(e.Data) == 0 {
+		return fmt.Sprintf("JSON-RPC error %d: %s", e.Code, e.Message)
+	}
+	return fmt.Sprintf("JSON-RPC error %d: %s: %s", e.Code, e.Message, string(e.Data))
+}
+
+// IsValidation reports whether error is caused by validation of the path or value.
+func (e *JSONRPCError) IsValidation() bool {
+	return e.Validation != ""
+}
+
+// DecodeData decodes data payload of the error into v.
+func (e *JSONRPCError) DecodeData(v interface{}) error {
+	return json.Unmarshal(e.Data, v)
+}
@@ -0,0 +1,82 @@
+package srljrpc
+
+import (
+	"context"
+	"sync"
+
+	"github.com/azyablov/srljrpc/apierr"
+)
+
+// Default limit of in-flight asynchronous requests per client.
+const defMaxInFlight = 8
+
+// Result type to represent the outcome of the asynchronous request sent by DoAsync.
+type Result struct {
+	Request  Requester
+	Response *Response
+	Err      error
+}
+
+// ClientOption to set the limit of in-flight asynchronous requests sent by DoAsync, 8 by default.
+// Requests exceeding the limit wait for the slot, synchronous requests aren't limited.
+func WithOptMaxInFlight(n int) ClientOption {
+	return func(c *JSONRPCClient) error {
+		if n < 1 {
+			return apierr.NewClientError(apierr.CodeClntMaxInFlight, nil)
+		}
+		c.inFlight = make(chan struct{}, n)
+		return nil
+	}
+}
+
+// DoAsync calls the JSON RPC server in the background and returns the channel delivering exactly one Result, then closed.
+func (c *JSONRPCClient) DoAsync(r Requester) <-chan Result {
+	return c.DoAsyncContext(context.Background(), r)
+}
+
+// DoAsyncContext is the same as DoAsync, but uses the provided context for the request and waiting for the in-flight slot.
+func (c *JSONRPCClient) DoAsyncContext(ctx context.Context, r Requester) <-chan Result {
+	ch := make(chan Result, 1)
+	go func() {
+		defer close(ch)
+		select {
+		case c.inFlight <- struct{}{}:
+		case <-ctx.Done():
+			ch <- Result{Request: r, Err: apierr.NewClientError(apierr.CodeClntCtxDone, ctx.Err())}
+			return
+		}
+		resp, err := c.DoContext(ctx, r)
+		<-c.inFlight
+		ch <- Result{Request: r, Response: resp, Err: err}
+	}()
+	return ch
+}
+
+// Collect waits for results of the asynchronous requests and returns them in order of channels provided.
+func Collect(chs ...<-chan Result) []Result {
+	results := make([]Result, len(chs))
+	for i, ch := range chs {
+		results[i] = <-ch
+	}
+	return results
+}
+
+// Merge returns the channel delivering results of the asynchronous requests as they complete, closed after the last one.
+func Merge(chs ...<-chan Result) <-chan Result {
+	out := make(chan Result, len(chs))
+	var wg sync.WaitGroup
+	wg.Add(len(chs))
+	for _, ch := range chs {
+		go func(ch <-chan Result) {
+			defer wg.Done()
+			for res := range ch {
+				out <- res
+			}
+		}(ch)
+	}
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out
+}
@@ -0,0 +1,110 @@
+//go:build unit
+
+package srljrpc_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/azyablov/srljrpc"
+	"github.com/azyablov/srljrpc/actions"
+	"github.com/azyablov/srljrpc/apierr"
+	"github.com/azyablov/srljrpc/datastores"
+	"github.com/azyablov/srljrpc/methods"
+)
+
+// Helper function to create GET request of the STATE datastore for the path.
+func helperStateReq(t *testing.T, path string) *srljrpc.Request {
+	t.Helper()
+	cmd, err := srljrpc.NewCommand(actions.NONE, path, srljrpc.CommandValue(""), srljrpc.WithDatastore(datastores.STATE))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	r, err := srljrpc.NewRequest(methods.GET, []*srljrpc.Command{cmd})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return r
+}
+
+func TestDoAsync(t *testing.T) {
+	var (
+		mu           sync.Mutex
+		cur, maxSeen int
+	)
+	limiter := func(next srljrpc.Handler) srljrpc.Handler {
+		return func(ctx context.Context, r srljrpc.Requester) (*srljrpc.Response, error) {
+			mu.Lock()
+			cur++
+			if cur > maxSeen {
+				maxSeen = cur
+			}
+			mu.Unlock()
+			time.Sleep(20 * time.Millisecond)
+			defer func() {
+				mu.Lock()
+				cur--
+				mu.Unlock()
+			}()
+			return next(ctx, r)
+		}
+	}
+	s, _ := helperSimClient(t)
+	c, err := s.NewClient(srljrpc.WithOptMaxInFlight(2), srljrpc.WithOptMiddleware(limiter))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	mu.Lock()
+	maxSeen = 0
+	mu.Unlock()
+
+	paths := []string{"/system/name/host-name", "/system/information/version", "/system/invalid", "/platform/chassis", "/system/name/host-name", "/system/information"}
+	var chs []<-chan srljrpc.Result
+	for _, p := range paths {
+		chs = append(chs, c.DoAsync(helperStateReq(t, p)))
+	}
+	results := srljrpc.Collect(chs...)
+	for i, res := range results {
+		expErr := error(nil)
+		if paths[i] == "/system/invalid" {
+			expErr = apierr.ErrClntJSONRPCResp
+		}
+		if !errors.Is(res.Err, expErr) {
+			t.Errorf("%s: expected error %v, got %v", paths[i], expErr, res.Err)
+		}
+		if res.Err == nil && res.Response.GetID() != res.Request.GetID() {
+			t.Errorf("%s: unexpected result %+v", paths[i], res)
+		}
+	}
+	if maxSeen != 2 {
+		t.Errorf("expected at most 2 requests in-flight, got %d", maxSeen)
+	}
+
+	// as they complete
+	chs = chs[:0]
+	for _, p := range paths[:3] {
+		chs = append(chs, c.DoAsync(helperStateReq(t, p)))
+	}
+	n := 0
+	for range srljrpc.Merge(chs...) {
+		n++
+	}
+	if n != 3 {
+		t.Errorf("expected 3 results, got %d", n)
+	}
+
+	// cancelled while waiting for in-flight slot
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	res := <-c.DoAsyncContext(ctx, helperStateReq(t, paths[0]))
+	if !errors.Is(res.Err, apierr.ErrClntCtxDone) {
+		t.Errorf("expected error %v, got %v", apierr.ErrClntCtxDone, res.Err)
+	}
+
+	if _, err := s.NewClient(srljrpc.WithOptMaxInFlight(0)); !errors.Is(err, apierr.ErrClntMaxInFlight) {
+		t.Errorf("expected error %v, got %v", apierr.ErrClntMaxInFlight, err)
+	}
+}
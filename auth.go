@@ -0,0 +1,180 @@
+package srljrpc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/azyablov/srljrpc/apierr"
+)
+
+// Authenticator is the interface implemented by credential providers to authenticate HTTP requests sent to the target.
+// Authenticate is called for every HTTP request, so credentials could be rotated w/o client re-creation.
+type Authenticator interface {
+	Authenticate(ctx context.Context, r *http.Request) error
+}
+
+// AuthenticatorFunc type is an adapter to allow the use of ordinary functions as Authenticator.
+type AuthenticatorFunc func(ctx context.Context, r *http.Request) error
+
+// Authenticate calls f(ctx, r).
+func (f AuthenticatorFunc) Authenticate(ctx context.Context, r *http.Request) error {
+	return f(ctx, r)
+}
+
+// CredentialsFunc type to represent a callback returning current username and password, e.g. fetched from secrets store.
+type CredentialsFunc func(ctx context.Context) (username, password string, err error)
+
+// basicAuth type to represent static Basic authentication set by WithOptCredentials.
+type basicAuth struct {
+	username *string
+	password *string
+}
+
+func (a basicAuth) Authenticate(ctx context.Context, r *http.Request) error {
+	r.SetBasicAuth(*a.username, *a.password)
+	return nil
+}
+
+// certAuth type to represent client certificate only authentication.
+type certAuth struct{}
+
+func (certAuth) Authenticate(ctx context.Context, r *http.Request) error {
+	return nil
+}
+
+// BasicAuth returns Authenticator with static username and password.
+func BasicAuth(username, password string) Authenticator {
+	return basicAuth{username: &username, password: &password}
+}
+
+// BasicAuthFunc returns Authenticator using the callback to get username and password for every request.
+func BasicAuthFunc(f CredentialsFunc) Authenticator {
+	return AuthenticatorFunc(func(ctx context.Context, r *http.Request) error {
+		u, p, err := f(ctx)
+		if err != nil {
+			return err
+		}
+		r.SetBasicAuth(u, p)
+		return nil
+	})
+}
+
+// EnvAuth returns Authenticator reading username and password from the environment variables.
+func EnvAuth(userVar, passVar string) Authenticator {
+	return BasicAuthFunc(func(ctx context.Context) (string, string, error) {
+		u, ok := os.LookupEnv(userVar)
+		if !ok {
+			return "", "", fmt.Errorf("environment variable %s is not set", userVar)
+		}
+		p, ok := os.LookupEnv(passVar)
+		if !ok {
+			return "", "", fmt.Errorf("environment variable %s is not set", passVar)
+		}
+		return u, p, nil
+	})
+}
+
+// FileAuth returns Authenticator reading credentials from the file with the first line in form username:password.
+// The file is read for every request, so updated credentials are picked up immediately.
+func FileAuth(path string) Authenticator {
+	return BasicAuthFunc(func(ctx context.Context) (string, string, error) {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return "", "", err
+		}
+		line := strings.TrimSpace(strings.SplitN(string(b), "\n", 2)[0])
+		i := strings.IndexByte(line, ':')
+		if i <= 0 {
+			return "", "", fmt.Errorf("credentials file %s must contain username:password", path)
+		}
+		return line[:i], line[i+1:], nil
+	})
+}
+
+// NetrcAuth returns Authenticator looking up login and password for the target host in netrc file,
+// entry with default keyword is used if there is no entry for the host.
+func NetrcAuth(path string) Authenticator {
+	return AuthenticatorFunc(func(ctx context.Context, r *http.Request) error {
+		u, p, err := netrcLookup(path, r.URL.Hostname())
+		if err != nil {
+			return err
+		}
+		r.SetBasicAuth(u, p)
+		return nil
+	})
+}
+
+// CertAuth returns Authenticator for client certificate only authentication, so Authorization header isn't sent.
+// Requires client certificate and key provided by WithOptTLS.
+func CertAuth() Authenticator {
+	return certAuth{}
+}
+
+// ClientOption to set Authenticator, which takes precedence over credentials set by WithOptCredentials.
+func WithOptAuth(a Authenticator) ClientOption {
+	return func(c *JSONRPCClient) error {
+		if a == nil {
+			return apierr.NewClientError(apierr.CodeClntAuth, fmt.Errorf("authenticator is nil"))
+		}
+		c.auth = a
+		return nil
+	}
+}
+
+// Helper function to find credentials for the machine in netrc file.
+func netrcLookup(path, machine string) (string, string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", "", err
+	}
+	type entry struct{ machine, login, password string }
+	var (
+		entries []*entry
+		cur     *entry
+	)
+	toks := strings.Fields(string(b))
+	for i := 0; i < len(toks); i++ {
+		switch toks[i] {
+		case "default":
+			cur = &entry{}
+			entries = append(entries, cur)
+			continue
+		case "machine", "login", "password":
+		default:
+			continue
+		}
+		if i+1 == len(toks) {
+			break
+		}
+		switch toks[i] {
+		case "machine":
+			cur = &entry{machine: toks[i+1]}
+			entries = append(entries, cur)
+		case "login":
+			if cur != nil {
+				cur.login = toks[i+1]
+			}
+		case "password":
+			if cur != nil {
+				cur.password = toks[i+1]
+			}
+		}
+		i++
+	}
+	var def *entry
+	for _, e := range entries {
+		if e.machine == machine {
+			return e.login, e.password, nil
+		}
+		if e.machine == "" && def == nil {
+			def = e
+		}
+	}
+	if def == nil {
+		return "", "", fmt.Errorf("no netrc entry for %s in %s", machine, path)
+	}
+	return def.login, def.password, nil
+}
@@ -0,0 +1,165 @@
+//go:build unit
+
+package srljrpc_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/azyablov/srljrpc"
+	"github.com/azyablov/srljrpc/apierr"
+	"github.com/azyablov/srljrpc/srljrpctest"
+)
+
+func TestAuthenticators(t *testing.T) {
+	s := srljrpctest.NewServer(srljrpctest.WithCredentials("netops", "s3cr3t"))
+	t.Cleanup(s.Close)
+	dir := t.TempDir()
+
+	credFile := filepath.Join(dir, "creds")
+	if err := os.WriteFile(credFile, []byte("netops:s3cr3t\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	badCredFile := filepath.Join(dir, "bad")
+	if err := os.WriteFile(badCredFile, []byte("netops\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	netrcFile := filepath.Join(dir, "netrc")
+	if err := os.WriteFile(netrcFile, []byte("machine leaf1 login admin password admin\nmachine "+s.Host+"\n  login netops\n  password s3cr3t\ndefault login admin password NokiaSrl1!\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	defNetrcFile := filepath.Join(dir, "defnetrc")
+	if err := os.WriteFile(defNetrcFile, []byte("machine leaf1 login admin password admin\ndefault login netops password s3cr3t\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("SRL_TEST_USER", "netops")
+	t.Setenv("SRL_TEST_PASS", "s3cr3t")
+
+	rotated := "wrong"
+	var testData = []struct {
+		testName string
+		auth     srljrpc.Authenticator
+		expErr   error
+	}{
+		{"Static basic", srljrpc.BasicAuth("netops", "s3cr3t"), nil},
+		{"Static basic with wrong password", srljrpc.BasicAuth("netops", "admin"), apierr.ErrClntHTTPStatus},
+		{"Environment", srljrpc.EnvAuth("SRL_TEST_USER", "SRL_TEST_PASS"), nil},
+		{"Environment variable isn't set", srljrpc.EnvAuth("SRL_TEST_USER", "SRL_TEST_NO_PASS"), apierr.ErrClntAuth},
+		{"File", srljrpc.FileAuth(credFile), nil},
+		{"File doesn't exist", srljrpc.FileAuth(filepath.Join(dir, "none")), apierr.ErrClntAuth},
+		{"File without password", srljrpc.FileAuth(badCredFile), apierr.ErrClntAuth},
+		{"Netrc machine", srljrpc.NetrcAuth(netrcFile), nil},
+		{"Netrc default", srljrpc.NetrcAuth(defNetrcFile), nil},
+		{"Netrc without entry", srljrpc.NetrcAuth(credFile), apierr.ErrClntAuth},
+		{"Rotated by callback", srljrpc.BasicAuthFunc(func(ctx context.Context) (string, string, error) {
+			p := rotated
+			rotated = "s3cr3t"
+			return "netops", p, nil
+		}), apierr.ErrClntHTTPStatus},
+		{"Callback error", srljrpc.BasicAuthFunc(func(ctx context.Context) (string, string, error) {
+			return "", "", errors.New("vault is sealed")
+		}), apierr.ErrClntAuth},
+	}
+	for _, td := range testData {
+		t.Run(td.testName, func(t *testing.T) {
+			_, err := s.NewClient(srljrpc.WithOptAuth(td.auth))
+			if !errors.Is(err, td.expErr) {
+				t.Errorf("expected error %v, got %v", td.expErr, err)
+			}
+		})
+	}
+
+	// credentials are picked up on every request, so the rotated password is used by the next client
+	if _, err := s.NewClient(srljrpc.WithOptAuth(srljrpc.BasicAuthFunc(func(ctx context.Context) (string, string, error) {
+		return "netops", rotated, nil
+	}))); err != nil {
+		t.Errorf("unexpected error with rotated password: %v", err)
+	}
+	if _, err := s.NewClient(srljrpc.WithOptAuth(nil)); !errors.Is(err, apierr.ErrClntAuth) {
+		t.Errorf("expected error %v, got %v", apierr.ErrClntAuth, err)
+	}
+}
+
+func TestCertAuth(t *testing.T) {
+	s := srljrpctest.NewServer(srljrpctest.WithClientCertAuth())
+	t.Cleanup(s.Close)
+	dir := t.TempDir()
+	caFile, certFile, keyFile := filepath.Join(dir, "ca.pem"), filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	helperWritePEM(t, caFile, "CERTIFICATE", s.Certificate().Raw)
+	helperClientCert(t, certFile, keyFile)
+
+	skipVerify := false
+	if _, err := s.NewClient(srljrpc.WithOptAuth(srljrpc.CertAuth())); !errors.Is(err, apierr.ErrClntAuthNoCert) {
+		t.Errorf("expected error %v, got %v", apierr.ErrClntAuthNoCert, err)
+	}
+	c, err := s.NewClient(
+		srljrpc.WithOptTLS(&srljrpc.TLSAttr{CAFile: &caFile, CertFile: &certFile, KeyFile: &keyFile, SkipVerify: &skipVerify}),
+		srljrpc.WithOptAuth(srljrpc.CertAuth()))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.GetHostname() != srljrpctest.DefaultHostname {
+		t.Errorf("expected hostname %s, got %s", srljrpctest.DefaultHostname, c.GetHostname())
+	}
+
+	// client certificate is loaded without CA file when server verification is skipped
+	skipVerify = true
+	c, err = s.NewClient(
+		srljrpc.WithOptTLS(&srljrpc.TLSAttr{CertFile: &certFile, KeyFile: &keyFile, SkipVerify: &skipVerify}),
+		srljrpc.WithOptAuth(srljrpc.CertAuth()))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.GetHostname() != srljrpctest.DefaultHostname {
+		t.Errorf("expected hostname %s, got %s", srljrpctest.DefaultHostname, c.GetHostname())
+	}
+	noKey := ""
+	if _, err := s.NewClient(srljrpc.WithOptTLS(&srljrpc.TLSAttr{CertFile: &certFile, KeyFile: &noKey, SkipVerify: &skipVerify})); !errors.Is(err, apierr.ErrClntTLSFilesUnspecified) {
+		t.Errorf("expected error %v, got %v", apierr.ErrClntTLSFilesUnspecified, err)
+	}
+}
+
+// Helper function to generate self-signed client certificate and key.
+func helperClientCert(t *testing.T, certFile, keyFile string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "netops"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kb, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	helperWritePEM(t, certFile, "CERTIFICATE", der)
+	helperWritePEM(t, keyFile, "EC PRIVATE KEY", kb)
+}
+
+// Helper function to write PEM encoded block into the file.
+func helperWritePEM(t *testing.T, file, typ string, b []byte) {
+	t.Helper()
+	if err := os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: b}), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,175 @@
+package srljrpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/azyablov/srljrpc/actions"
+	"github.com/azyablov/srljrpc/apierr"
+	"github.com/azyablov/srljrpc/datastores"
+	"github.com/azyablov/srljrpc/formats"
+	"github.com/azyablov/srljrpc/methods"
+	"github.com/azyablov/srljrpc/yms"
+)
+
+// ConfigBackup type to represent a snapshot of RUNNING datastore written by Backup and replayed by Restore.
+// Configuration trees are kept as canonical JSON: object keys are sorted and numbers are preserved as is.
+type ConfigBackup struct {
+	Hostname string          `json:"hostname,omitempty"`
+	Version  string          `json:"version,omitempty"`
+	Created  time.Time       `json:"created"`
+	SRL      json.RawMessage `json:"srl,omitempty"`
+	OC       json.RawMessage `json:"oc,omitempty"`
+}
+
+// BackupOption is a function type that applies options to Backup.
+type BackupOption func(*backupOpts)
+
+// RestoreOption is a function type that applies options to Restore.
+type RestoreOption func(*restoreOpts)
+
+type backupOpts struct {
+	oc bool
+}
+
+type restoreOpts struct {
+	ym     yms.EnumYmType
+	dryRun bool
+}
+
+// BackupOption to include configuration in OpenConfig yang models in addition to SRL yang models.
+func WithBackupOC() BackupOption {
+	return func(o *backupOpts) {
+		o.oc = true
+	}
+}
+
+// RestoreOption to replay configuration in the provided yang models. Default is SRL.
+func WithRestoreYm(ym yms.EnumYmType) RestoreOption {
+	return func(o *restoreOpts) {
+		o.ym = ym
+	}
+}
+
+// RestoreOption to execute DIFF request instead of SET, so difference between the backup and RUNNING datastore is returned w/o applying it.
+// Dry-run is diff-only: nothing is applied, call Restore w/o the option to apply the backup after review.
+func WithRestoreDryRun() RestoreOption {
+	return func(o *restoreOpts) {
+		o.dryRun = true
+	}
+}
+
+// Backup writes RUNNING datastore of the target into w as indented JSON document of ConfigBackup type.
+func (c *JSONRPCClient) Backup(w io.Writer, opts ...BackupOption) error {
+	return c.BackupContext(context.Background(), w, opts...)
+}
+
+// BackupContext is the same as Backup, but uses the provided context for the requests.
+func (c *JSONRPCClient) BackupContext(ctx context.Context, w io.Writer, opts ...BackupOption) error {
+	var o backupOpts
+	for _, opt := range opts {
+		opt(&o)
+	}
+	b := ConfigBackup{Hostname: c.GetHostname(), Version: c.GetSysVer(), Created: time.Now().UTC()}
+	var err error
+	if b.SRL, err = c.backupTree(ctx, yms.SRL); err != nil {
+		return err
+	}
+	if o.oc {
+		if b.OC, err = c.backupTree(ctx, yms.OC); err != nil {
+			return err
+		}
+	}
+	out, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return apierr.NewClientError(apierr.CodeClntBackupWrite, err)
+	}
+	if _, err := w.Write(append(out, '\n')); err != nil {
+		return apierr.NewClientError(apierr.CodeClntBackupWrite, err)
+	}
+	return nil
+}
+
+// Helper method to get the whole RUNNING datastore in provided yang models as canonical JSON.
+func (c *JSONRPCClient) backupTree(ctx context.Context, ym yms.EnumYmType) (json.RawMessage, error) {
+	cmd, err := NewCommand(actions.NONE, "/", CommandValue(""), WithDatastore(datastores.RUNNING))
+	if err != nil {
+		return nil, apierr.NewClientError(apierr.CodeClntCmdCreation, err)
+	}
+	r, err := NewRequest(methods.GET, []*Command{cmd}, WithYmType(ym))
+	if err != nil {
+		return nil, apierr.NewClientError(apierr.CodeClntRPCReqCreation, err)
+	}
+	resp, err := c.DoContext(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := DecodeResult[json.RawMessage](r, resp, 0)
+	if err != nil {
+		return nil, err
+	}
+	// decoding into generic tree and encoding back sorts object keys
+	var tree interface{}
+	d := json.NewDecoder(bytes.NewReader(raw))
+	d.UseNumber()
+	if err := d.Decode(&tree); err != nil {
+		return nil, &ResultError{Index: 0, Path: "/", Err: apierr.NewClientError(apierr.CodeClntResultDecoding, err)}
+	}
+	return json.Marshal(tree)
+}
+
+// ReadBackup reads ConfigBackup written by Backup from r.
+func ReadBackup(r io.Reader) (*ConfigBackup, error) {
+	var b ConfigBackup
+	if err := json.NewDecoder(r).Decode(&b); err != nil {
+		return nil, apierr.NewClientError(apierr.CodeClntBackupRead, err)
+	}
+	return &b, nil
+}
+
+// Restore replays configuration backup read from r as a single REPLACE of the root path against CANDIDATE datastore.
+// ct is the timeout in seconds for the confirm operation, set to 0 to disable.
+// With WithRestoreDryRun() the same change is sent as DIFF request (like BulkDiff), so nothing is applied and ct is ignored.
+func (c *JSONRPCClient) Restore(r io.Reader, ct int, opts ...RestoreOption) (*Response, error) {
+	return c.RestoreContext(context.Background(), r, ct, opts...)
+}
+
+// RestoreContext is the same as Restore, but uses the provided context for the request.
+func (c *JSONRPCClient) RestoreContext(ctx context.Context, r io.Reader, ct int, opts ...RestoreOption) (*Response, error) {
+	o := restoreOpts{ym: yms.SRL}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	b, err := ReadBackup(r)
+	if err != nil {
+		return nil, err
+	}
+	tree := b.SRL
+	if o.ym == yms.OC {
+		tree = b.OC
+	}
+	if len(tree) == 0 || string(tree) == "null" {
+		return nil, apierr.NewClientError(apierr.CodeClntBackupNoConfig, nil)
+	}
+
+	cmd, err := NewCommand(actions.REPLACE, "/", CommandValue(tree), WithJSONValue())
+	if err != nil {
+		return nil, apierr.NewClientError(apierr.CodeClntCmdCreation, err)
+	}
+	var req *Request
+	switch {
+	case o.dryRun:
+		req, err = NewRequest(methods.DIFF, []*Command{cmd}, WithRequestDatastore(datastores.CANDIDATE), WithYmType(o.ym), WithOutputFormat(formats.JSON))
+	case ct == 0:
+		req, err = NewRequest(methods.SET, []*Command{cmd}, WithRequestDatastore(datastores.CANDIDATE), WithYmType(o.ym), WithOutputFormat(formats.JSON))
+	default:
+		req, err = NewRequest(methods.SET, []*Command{cmd}, WithRequestDatastore(datastores.CANDIDATE), WithYmType(o.ym), WithOutputFormat(formats.JSON), WithConfirmTimeout(ct))
+	}
+	if err != nil {
+		return nil, apierr.NewClientError(apierr.CodeClntRPCReqCreation, err)
+	}
+	return c.DoContext(ctx, req)
+}
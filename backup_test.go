@@ -0,0 +1,83 @@
+//go:build unit
+
+package srljrpc_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/azyablov/srljrpc"
+	"github.com/azyablov/srljrpc/apierr"
+	"github.com/azyablov/srljrpc/yms"
+)
+
+func TestBackupRestore(t *testing.T) {
+	s, c := helperSimClient(t)
+	descPath := "/interface[name=system0]/description"
+
+	if _, err := c.Update(0, srljrpc.PV{Path: descPath, Value: "BEFORE"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := c.Backup(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	saved := buf.String()
+	b, err := srljrpc.ReadBackup(strings.NewReader(saved))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if b.Hostname != c.GetHostname() || b.Version != c.GetSysVer() || b.Created.IsZero() {
+		t.Errorf("unexpected backup metadata: %+v", b)
+	}
+	if !bytes.Contains(b.SRL, []byte(`"BEFORE"`)) || b.OC != nil {
+		t.Errorf("unexpected backup content: %s", saved)
+	}
+
+	// Backup is canonical, so the same configuration produces the same trees
+	buf.Reset()
+	if err := c.Backup(&buf, srljrpc.WithBackupOC()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	bOC, err := srljrpc.ReadBackup(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.Equal(b.SRL, bOC.SRL) || len(bOC.OC) == 0 {
+		t.Errorf("unexpected backup with OC:\n%s\n%s", b.SRL, bOC.OC)
+	}
+
+	if _, err := c.Update(0, srljrpc.PV{Path: descPath, Value: "AFTER"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Dry run reports the difference w/o applying it
+	resp, err := c.Restore(strings.NewReader(saved), 0, srljrpc.WithRestoreDryRun())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	diff, err := srljrpc.DecodeResult[string](nil, resp, 0)
+	if err != nil || !strings.Contains(diff, "BEFORE") || !strings.Contains(diff, "AFTER") {
+		t.Errorf("unexpected diff: %s, %v", diff, err)
+	}
+	if v, _ := s.Get("running", descPath); v != "AFTER" {
+		t.Errorf("expected AFTER after dry run, got %v", v)
+	}
+
+	if _, err := c.Restore(strings.NewReader(saved), 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v, _ := s.Get("running", descPath); v != "BEFORE" {
+		t.Errorf("expected BEFORE after restore, got %v", v)
+	}
+
+	// Errors
+	if _, err := c.Restore(strings.NewReader(saved), 0, srljrpc.WithRestoreYm(yms.OC)); !errors.Is(err, apierr.ErrClntBackupNoConfig) {
+		t.Errorf("expected error %v, got %v", apierr.ErrClntBackupNoConfig, err)
+	}
+	if _, err := c.Restore(strings.NewReader("{"), 0); !errors.Is(err, apierr.ErrClntBackupRead) {
+		t.Errorf("expected error %v, got %v", apierr.ErrClntBackupRead, err)
+	}
+}
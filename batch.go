@@ -0,0 +1,164 @@
+package srljrpc
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/azyablov/srljrpc/apierr"
+	"github.com/azyablov/srljrpc/formats"
+	"github.com/azyablov/srljrpc/methods"
+)
+
+// Method reported for batches to middlewares, logs, metrics and spans.
+const batchMethod methods.EnumMethods = "batch"
+
+// Batch type to represent JSON RPC 2.0 batch: a set of requests (get / set / validate / diff / cli) sent in a single HTTP POST as an array.
+type Batch struct {
+	requests []Requester
+	ids      map[int]bool
+}
+
+// BatchResult type to represent a result of the single request of the batch.
+// Err is set to ClientError with code CodeClntJSONRPCResp if the server returned an error for the request
+// or CodeClntBatchNoResp if the response for the request is missing.
+type BatchResult struct {
+	Request  Requester
+	Response *Response
+	Err      error
+}
+
+// Creates a new Batch from the requests in order of appearance.
+func NewBatch(rs ...Requester) (*Batch, error) {
+	b := &Batch{ids: map[int]bool{}}
+	for _, r := range rs {
+		if err := b.Add(r); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+// Add appends the request to the batch. Requests must have unique IDs, since responses are matched by ID.
+func (b *Batch) Add(r Requester) error {
+	if r == nil {
+		return apierr.NewClientError(apierr.CodeClntBatchEmpty, nil)
+	}
+	if b.ids[r.GetID()] {
+		return apierr.NewClientError(apierr.CodeClntBatchDuplicateID, nil)
+	}
+	b.ids[r.GetID()] = true
+	b.requests = append(b.requests, r)
+	return nil
+}
+
+// Len returns number of requests in the batch.
+func (b *Batch) Len() int {
+	return len(b.requests)
+}
+
+// Requests returns requests of the batch.
+func (b *Batch) Requests() []Requester {
+	return b.requests
+}
+
+// Marshaling of the Batch into JSON array of requests.
+func (b *Batch) Marshal() ([]byte, error) {
+	if len(b.requests) == 0 {
+		return nil, apierr.NewClientError(apierr.CodeClntBatchEmpty, nil)
+	}
+	msgs := make([]json.RawMessage, 0, len(b.requests))
+	for _, r := range b.requests {
+		m, err := r.Marshal()
+		if err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, m)
+	}
+	return json.Marshal(msgs)
+}
+
+// Calls the JSON RPC server with the batch and returns per-request results in order of requests in the batch.
+func (c *JSONRPCClient) DoBatch(b *Batch) ([]BatchResult, error) {
+	return c.DoBatchContext(context.Background(), b)
+}
+
+// DoBatchContext is the same as DoBatch, but uses the provided context for the request.
+// Returned error is non nil if the batch as a whole failed, e.g. HTTP request failure, while per-request errors are reported in BatchResult.
+// The batch isn't sent if any of requests isn't supported by the target release, see Capabilities.
+func (c *JSONRPCClient) DoBatchContext(ctx context.Context, b *Batch) ([]BatchResult, error) {
+	if b == nil {
+		return nil, apierr.NewClientError(apierr.CodeClntBatchEmpty, nil)
+	}
+	if _, err := b.Marshal(); err != nil {
+		if _, ok := err.(apierr.ClientError); ok {
+			return nil, err
+		}
+		return nil, apierr.NewClientError(apierr.CodeClntReqMarshalling, err)
+	}
+
+	// batch is passed through middlewares, logging, metrics, tracing and capabilities check as a single request
+	br := &batchRequest{b: b}
+	if _, err := c.DoContext(ctx, br); err != nil {
+		return nil, err
+	}
+	rpcResps := br.resps
+
+	// demultiplexing responses by ID
+	byID := make(map[int]*Response, len(rpcResps))
+	for i := range rpcResps {
+		byID[rpcResps[i].GetID()] = &rpcResps[i]
+	}
+	results := make([]BatchResult, len(b.requests))
+	for i, r := range b.requests {
+		results[i].Request = r
+		resp, ok := byID[r.GetID()]
+		switch {
+		case !ok:
+			results[i].Err = apierr.NewClientError(apierr.CodeClntBatchNoResp, nil)
+		case resp.Error != nil:
+			results[i].Response = resp
+			results[i].Err = apierr.NewClientError(apierr.CodeClntJSONRPCResp, resp.Error.jsonRPCError())
+		default:
+			results[i].Response = resp
+		}
+	}
+	return results, nil
+}
+
+// batchRequest type to pass the batch through the handler chain of the client as a single Requester,
+// method "batch" and ID of the first request are reported, while responses are collected by do.
+type batchRequest struct {
+	b     *Batch
+	resps []Response
+}
+
+func (br *batchRequest) Marshal() ([]byte, error) {
+	return br.b.Marshal()
+}
+
+func (br *batchRequest) GetMethod() (methods.EnumMethods, error) {
+	return batchMethod, nil
+}
+
+func (br *batchRequest) GetID() int {
+	return br.b.requests[0].GetID()
+}
+
+func (br *batchRequest) SetOutputFormat(of formats.EnumOutputFormats) error {
+	for _, r := range br.b.requests {
+		if err := r.SetOutputFormat(of); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Single attempt to call the JSON RPC server with the batch, facilitates do.
+func (c *JSONRPCClient) doBatch(ctx context.Context, br *batchRequest) error {
+	body, err := br.Marshal()
+	if err != nil {
+		return apierr.NewClientError(apierr.CodeClntReqMarshalling, err)
+	}
+	br.resps = nil
+	return c.send(ctx, body, &br.resps)
+}
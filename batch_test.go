@@ -0,0 +1,119 @@
+//go:build unit
+
+package srljrpc_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/azyablov/srljrpc"
+	"github.com/azyablov/srljrpc/apierr"
+	"github.com/azyablov/srljrpc/datastores"
+	"github.com/azyablov/srljrpc/formats"
+	"github.com/azyablov/srljrpc/srljrpctest"
+	"github.com/azyablov/srljrpc/yms"
+)
+
+func TestDoBatch(t *testing.T) {
+	s, c := helperSimClient(t)
+
+	get, err := srljrpc.NewGetRequest([]string{"/system/name/host-name"}, false, false, formats.JSON, datastores.RUNNING)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cli, err := srljrpc.NewCLIRequest([]string{"show version"}, formats.JSON)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	val, err := srljrpc.NewValidateRequest(nil, nil, []srljrpc.PV{{Path: "/interface[name=system0]/description", Value: "test"}}, yms.SRL, formats.JSON, datastores.CANDIDATE)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	inv, err := srljrpc.NewGetRequest([]string{"/system/invalid"}, false, false, formats.JSON, datastores.RUNNING)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b, err := srljrpc.NewBatch(get, cli, val, inv)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	before := s.Requests()
+	results, err := c.DoBatch(b)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := s.Requests() - before; n != 4 {
+		t.Errorf("expected 4 requests served, got %d", n)
+	}
+	if len(results) != 4 {
+		t.Fatalf("expected 4 results, got %d", len(results))
+	}
+	if results[0].Err != nil || results[0].Request != get || string(results[0].Response.Result) != `["`+c.GetHostname()+`"]` {
+		t.Errorf("unexpected GET result: %v, %v", results[0].Response, results[0].Err)
+	}
+	if results[1].Err != nil || results[1].Response.GetID() != cli.GetID() {
+		t.Errorf("unexpected CLI result: %v, %v", results[1].Response, results[1].Err)
+	}
+	if results[2].Err != nil || string(results[2].Response.Result) != `[{}]` {
+		t.Errorf("unexpected VALIDATE result: %v, %v", results[2].Response, results[2].Err)
+	}
+	if !errors.Is(results[3].Err, apierr.ErrClntJSONRPCResp) || results[3].Response == nil || results[3].Response.Error == nil {
+		t.Errorf("expected error %v with RpcError, got %v, %v", apierr.ErrClntJSONRPCResp, results[3].Response, results[3].Err)
+	}
+
+	// Batch validation
+	if err := b.Add(get); !errors.Is(err, apierr.ErrClntBatchDuplicateID) {
+		t.Errorf("expected error %v, got %v", apierr.ErrClntBatchDuplicateID, err)
+	}
+	if _, err := srljrpc.NewBatch(get, nil); !errors.Is(err, apierr.ErrClntBatchEmpty) {
+		t.Errorf("expected error %v, got %v", apierr.ErrClntBatchEmpty, err)
+	}
+	empty, _ := srljrpc.NewBatch()
+	if _, err := c.DoBatch(empty); !errors.Is(err, apierr.ErrClntBatchEmpty) {
+		t.Errorf("expected error %v, got %v", apierr.ErrClntBatchEmpty, err)
+	}
+}
+
+func TestDoBatchChain(t *testing.T) {
+	s := srljrpctest.NewServer(srljrpctest.WithVersion("v22.11.2-116-g6e6d8d4e0b"))
+	t.Cleanup(s.Close)
+	var methods []string
+	o := &observer{}
+	c, err := s.NewClient(srljrpc.WithOptMetrics(o), srljrpc.WithOptMiddleware(srljrpc.BeforeSend(func(ctx context.Context, r srljrpc.Requester) (context.Context, error) {
+		m, _ := r.GetMethod()
+		methods = append(methods, string(m))
+		return ctx, nil
+	})))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	b := helperBatch(t)
+	if _, err := c.DoBatch(b); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(methods) != 2 || methods[1] != "batch" {
+		t.Errorf("expected batch passed through middleware, got %v", methods)
+	}
+	if len(o.obs) != 2 || o.obs[1].Method != "batch" || o.obs[1].Outcome != srljrpc.OutcomeSuccess || o.obs[1].BytesSent == 0 {
+		t.Errorf("expected batch observation, got %+v", o.obs)
+	}
+
+	// diff isn't supported by 22.11, so the batch isn't sent
+	diff, err := srljrpc.NewDiffRequest(nil, nil, []srljrpc.PV{{Path: "/interface[name=system0]/description", Value: "test"}}, yms.SRL, formats.JSON, datastores.CANDIDATE)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := b.Add(diff); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	before := s.Requests()
+	if _, err := c.DoBatch(b); !errors.Is(err, apierr.ErrClntUnsupported) {
+		t.Errorf("expected error %v, got %v", apierr.ErrClntUnsupported, err)
+	}
+	if s.Requests() != before {
+		t.Errorf("expected no requests sent, got %d", s.Requests()-before)
+	}
+}
@@ -0,0 +1,101 @@
+package srljrpc
+
+import (
+	"fmt"
+
+	"github.com/azyablov/srljrpc/apierr"
+	"github.com/azyablov/srljrpc/methods"
+	"github.com/azyablov/srljrpc/yms"
+)
+
+// Capability type to represent a JSON RPC feature available starting from a particular SR Linux release.
+type Capability string
+
+// Capabilities checked by the client before sending a request.
+const (
+	CapDiff           Capability = "diff"            // diff method
+	CapConfirmTimeout Capability = "confirm-timeout" // confirm-timeout parameter of set method
+	CapOCYangModels   Capability = "oc-yang-models"  // OpenConfig yang-models
+)
+
+// Capabilities type to represent the registry of capabilities and minimal SR Linux releases supporting them.
+type Capabilities map[Capability]Version
+
+// DefaultCapabilities returns a new copy of the registry used by the client unless replaced by WithOptCapabilities.
+func DefaultCapabilities() Capabilities {
+	return Capabilities{
+		CapDiff:           {Major: 23, Minor: 3},
+		CapConfirmTimeout: {Major: 23, Minor: 3},
+		CapOCYangModels:   {Major: 22, Minor: 11},
+	}
+}
+
+// Supports returns true if the capability is supported by the release v, capabilities absent in the registry are considered supported.
+func (cs Capabilities) Supports(cp Capability, v Version) bool {
+	min, ok := cs[cp]
+	return !ok || v.Compare(min) >= 0
+}
+
+// ClientOption to replace the registry of capabilities, e.g. to adjust it to the releases in use.
+// The registry is copied, so later changes of cs don't affect the client.
+func WithOptCapabilities(cs Capabilities) ClientOption {
+	return func(c *JSONRPCClient) error {
+		if cs == nil {
+			return apierr.NewClientError(apierr.CodeClntCapabilitiesIsNil, nil)
+		}
+		c.caps = make(Capabilities, len(cs))
+		for cp, v := range cs {
+			c.caps[cp] = v
+		}
+		return nil
+	}
+}
+
+// GetRelease returns the parsed system version of the target after verification, false if the version can't be parsed.
+func (c *JSONRPCClient) GetRelease() (Version, bool) {
+	c.infoMu.RLock()
+	defer c.infoMu.RUnlock()
+	if c.release == nil {
+		return Version{}, false
+	}
+	return *c.release, true
+}
+
+// Supports returns true if the capability is supported by the target, which is assumed if the system version can't be parsed.
+func (c *JSONRPCClient) Supports(cp Capability) bool {
+	v, ok := c.GetRelease()
+	return !ok || c.caps.Supports(cp, v)
+}
+
+// Helper method to check that the request could be handled by the target release, returns error with CodeClntUnsupported otherwise.
+func (c *JSONRPCClient) checkCapabilities(r Requester) error {
+	if br, ok := r.(*batchRequest); ok {
+		for _, r := range br.b.requests {
+			if err := c.checkCapabilities(r); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	req, ok := r.(*Request)
+	if !ok || req.Params == nil {
+		return nil
+	}
+	var needed []Capability
+	if m, _ := req.GetMethod(); m == methods.DIFF {
+		needed = append(needed, CapDiff)
+	}
+	if req.Params.ConfirmTimeout != nil {
+		needed = append(needed, CapConfirmTimeout)
+	}
+	if req.Params.YmType != nil && req.Params.YangModels == string(yms.OC) {
+		needed = append(needed, CapOCYangModels)
+	}
+	for _, cp := range needed {
+		if !c.Supports(cp) {
+			v, _ := c.GetRelease()
+			return apierr.NewClientError(apierr.CodeClntUnsupported, fmt.Errorf("%s requires %s or later, target runs %s", cp, c.caps[cp], v))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+//go:build unit
+
+package srljrpc_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/azyablov/srljrpc"
+	"github.com/azyablov/srljrpc/actions"
+	"github.com/azyablov/srljrpc/apierr"
+	"github.com/azyablov/srljrpc/datastores"
+	"github.com/azyablov/srljrpc/formats"
+	"github.com/azyablov/srljrpc/srljrpctest"
+	"github.com/azyablov/srljrpc/yms"
+)
+
+func TestCapabilities(t *testing.T) {
+	pv := srljrpc.PV{Path: "/system/name/host-name", Value: "srl2"}
+	ocReq := func() *srljrpc.Request {
+		r, err := srljrpc.NewSetRequest(nil, nil, []srljrpc.PV{{Path: "/system/config/hostname", Value: "srl2"}}, yms.OC, formats.JSON, datastores.CANDIDATE, 0)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return r
+	}
+
+	var testData = []struct {
+		testName string
+		version  string
+		opts     []srljrpc.ClientOption
+		call     func(c *srljrpc.JSONRPCClient) error
+		expErr   error
+	}{
+		{"Diff on 22.11", "v22.11.2-116-g6e6d8d4e0b", nil, func(c *srljrpc.JSONRPCClient) error {
+			_, err := c.DiffCandidate(actions.UPDATE, yms.SRL, pv)
+			return err
+		}, apierr.ErrClntUnsupported},
+		{"Confirm timeout on 22.11", "v22.11.2-116-g6e6d8d4e0b", nil, func(c *srljrpc.JSONRPCClient) error {
+			_, err := c.Update(30, pv)
+			return err
+		}, apierr.ErrClntUnsupported},
+		{"Update w/o confirm timeout on 22.11", "v22.11.2-116-g6e6d8d4e0b", nil, func(c *srljrpc.JSONRPCClient) error {
+			_, err := c.Update(0, pv)
+			return err
+		}, nil},
+		{"OpenConfig on 22.6", "v22.6.4-90-g3fc31a1e8b", nil, func(c *srljrpc.JSONRPCClient) error {
+			_, err := c.Do(ocReq())
+			return err
+		}, apierr.ErrClntUnsupported},
+		{"Diff on 23.3", srljrpctest.DefaultVersion, nil, func(c *srljrpc.JSONRPCClient) error {
+			_, err := c.DiffCandidate(actions.UPDATE, yms.SRL, pv)
+			return err
+		}, nil},
+		{"Diff on unknown version", "unknown", nil, func(c *srljrpc.JSONRPCClient) error {
+			_, err := c.DiffCandidate(actions.UPDATE, yms.SRL, pv)
+			return err
+		}, nil},
+		{"Custom registry", srljrpctest.DefaultVersion, []srljrpc.ClientOption{srljrpc.WithOptCapabilities(srljrpc.Capabilities{srljrpc.CapDiff: {Major: 99}})}, func(c *srljrpc.JSONRPCClient) error {
+			_, err := c.DiffCandidate(actions.UPDATE, yms.SRL, pv)
+			return err
+		}, apierr.ErrClntUnsupported},
+	}
+	for _, td := range testData {
+		t.Run(td.testName, func(t *testing.T) {
+			s := srljrpctest.NewServer(srljrpctest.WithVersion(td.version))
+			t.Cleanup(s.Close)
+			c, err := s.NewClient(td.opts...)
+			if err != nil {
+				t.Fatalf("can't create client: %v", err)
+			}
+			before := s.Requests()
+			err = td.call(c)
+			if !errors.Is(err, td.expErr) {
+				t.Fatalf("expected error %v, got %v", td.expErr, err)
+			}
+			if err != nil && s.Requests() != before {
+				t.Errorf("expected no request sent to the target, got %d", s.Requests()-before)
+			}
+		})
+	}
+}
+
+func TestGetRelease(t *testing.T) {
+	_, c := helperSimClient(t)
+	v, ok := c.GetRelease()
+	if !ok || v.String() != srljrpctest.DefaultVersion {
+		t.Errorf("expected release %s, got %s, %v", srljrpctest.DefaultVersion, v, ok)
+	}
+	if !c.Supports(srljrpc.CapDiff) || !srljrpc.DefaultCapabilities().Supports(srljrpc.CapDiff, v) {
+		t.Errorf("expected diff supported by %s", v)
+	}
+
+	_, c = helperSimClient(t, srljrpctest.WithVersion("unknown"))
+	if _, ok := c.GetRelease(); ok {
+		t.Errorf("expected no release for unknown version")
+	}
+
+	s, _ := helperSimClient(t)
+	if _, err := s.NewClient(srljrpc.WithOptCapabilities(nil)); !errors.Is(err, apierr.ErrClntCapabilitiesIsNil) {
+		t.Errorf("expected error %v, got %v", apierr.ErrClntCapabilitiesIsNil, err)
+	}
+
+	// registry is copied by the option
+	cs := srljrpc.DefaultCapabilities()
+	c, err := s.NewClient(srljrpc.WithOptCapabilities(cs))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cs[srljrpc.CapDiff] = srljrpc.Version{Major: 99}
+	if !c.Supports(srljrpc.CapDiff) {
+		t.Errorf("expected diff supported after registry passed to the option changed")
+	}
+}
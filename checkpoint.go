@@ -0,0 +1,118 @@
+package srljrpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/azyablov/srljrpc/actions"
+	"github.com/azyablov/srljrpc/apierr"
+	"github.com/azyablov/srljrpc/datastores"
+	"github.com/azyablov/srljrpc/methods"
+)
+
+// Paths used to manage configuration checkpoints.
+const (
+	checkpointStatePath    = "/system/configuration"
+	checkpointGeneratePath = "/system/configuration/generate-checkpoint"
+	checkpointPathFmt      = "/system/configuration/checkpoint[id=%d]/%s"
+)
+
+// Checkpoint type to represent configuration checkpoint metadata reported in STATE datastore.
+// Checkpoint with ID 0 is the most recent one.
+type Checkpoint struct {
+	ID       int       `json:"id"`
+	Name     string    `json:"name,omitempty"`
+	Comment  string    `json:"comment,omitempty"`
+	Username string    `json:"username,omitempty"`
+	Created  time.Time `json:"created"`
+	Version  string    `json:"version,omitempty"`
+}
+
+// CreateCheckpoint generates a new configuration checkpoint with optional name and comment via TOOLS datastore.
+func (c *JSONRPCClient) CreateCheckpoint(name, comment string) (*Response, error) {
+	return c.CreateCheckpointContext(context.Background(), name, comment)
+}
+
+// CreateCheckpointContext is the same as CreateCheckpoint, but uses the provided context for the request.
+// Name and comment are sent as JSON value of the single command, so the only checkpoint is generated.
+func (c *JSONRPCClient) CreateCheckpointContext(ctx context.Context, name, comment string) (*Response, error) {
+	var opts []CommandOption
+	var value CommandValue
+	if name != "" || comment != "" {
+		b, err := json.Marshal(struct {
+			Name    string `json:"name,omitempty"`
+			Comment string `json:"comment,omitempty"`
+		}{name, comment})
+		if err != nil {
+			return nil, apierr.NewClientError(apierr.CodeClntCmdCreation, err)
+		}
+		value = CommandValue(b)
+		opts = append(opts, WithJSONValue())
+	}
+	cmd, err := NewCommand(actions.UPDATE, checkpointGeneratePath, value, opts...)
+	if err != nil {
+		return nil, apierr.NewClientError(apierr.CodeClntCmdCreation, err)
+	}
+	r, err := NewRequest(methods.SET, []*Command{cmd}, WithRequestDatastore(datastores.TOOLS))
+	if err != nil {
+		return nil, apierr.NewClientError(apierr.CodeClntRPCReqCreation, err)
+	}
+	return c.DoContext(ctx, r)
+}
+
+// ListCheckpoints returns configuration checkpoints sorted by ID.
+func (c *JSONRPCClient) ListCheckpoints() ([]Checkpoint, error) {
+	return c.ListCheckpointsContext(context.Background())
+}
+
+// ListCheckpointsContext is the same as ListCheckpoints, but uses the provided context for the request.
+func (c *JSONRPCClient) ListCheckpointsContext(ctx context.Context) ([]Checkpoint, error) {
+	resp, err := c.StateContext(ctx, checkpointStatePath)
+	if err != nil {
+		return nil, err
+	}
+	conf, err := DecodeResult[map[string]json.RawMessage](nil, resp, 0)
+	if err != nil {
+		return nil, err
+	}
+	var cps []Checkpoint
+	for k, v := range conf {
+		// list could be module prefixed, e.g. srl_nokia-configuration:checkpoint
+		if k != "checkpoint" && !hasModulePrefix(k, "checkpoint") {
+			continue
+		}
+		if err := json.Unmarshal(v, &cps); err != nil {
+			return nil, &ResultError{Index: 0, Path: checkpointStatePath, Err: apierr.NewClientError(apierr.CodeClntResultDecoding, err)}
+		}
+	}
+	sort.Slice(cps, func(i, j int) bool { return cps[i].ID < cps[j].ID })
+	return cps, nil
+}
+
+// RevertCheckpoint reverts running configuration to the checkpoint with provided ID.
+func (c *JSONRPCClient) RevertCheckpoint(id int) (*Response, error) {
+	return c.RevertCheckpointContext(context.Background(), id)
+}
+
+// RevertCheckpointContext is the same as RevertCheckpoint, but uses the provided context for the request.
+func (c *JSONRPCClient) RevertCheckpointContext(ctx context.Context, id int) (*Response, error) {
+	return c.ToolsContext(ctx, PV{Path: fmt.Sprintf(checkpointPathFmt, id, "revert")})
+}
+
+// ClearCheckpoint removes the checkpoint with provided ID.
+func (c *JSONRPCClient) ClearCheckpoint(id int) (*Response, error) {
+	return c.ClearCheckpointContext(context.Background(), id)
+}
+
+// ClearCheckpointContext is the same as ClearCheckpoint, but uses the provided context for the request.
+func (c *JSONRPCClient) ClearCheckpointContext(ctx context.Context, id int) (*Response, error) {
+	return c.ToolsContext(ctx, PV{Path: fmt.Sprintf(checkpointPathFmt, id, "clear")})
+}
+
+// Helper function to check if the key is the name with module prefix.
+func hasModulePrefix(key, name string) bool {
+	return len(key) > len(name)+1 && key[len(key)-len(name)-1] == ':' && key[len(key)-len(name):] == name
+}
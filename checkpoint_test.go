@@ -0,0 +1,73 @@
+//go:build unit
+
+package srljrpc_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/azyablov/srljrpc"
+	"github.com/azyablov/srljrpc/apierr"
+)
+
+func TestCheckpoints(t *testing.T) {
+	s, c := helperSimClient(t)
+	descPath := "/interface[name=system0]/description"
+
+	cps, err := c.ListCheckpoints()
+	if err != nil || len(cps) != 0 {
+		t.Fatalf("expected no checkpoints, got %v, %v", cps, err)
+	}
+
+	// Checkpoint 1 with initial config, checkpoint 0 with updated one
+	if _, err := c.Update(0, srljrpc.PV{Path: descPath, Value: "INITIAL"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	before := s.Requests()
+	if _, err := c.CreateCheckpoint("initial", "before change"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s.Requests() != before+1 {
+		t.Errorf("expected checkpoint created by a single request, got %d", s.Requests()-before)
+	}
+	if _, err := c.Update(0, srljrpc.PV{Path: descPath, Value: "CHANGED"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := c.CreateCheckpoint("", ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cps, err = c.ListCheckpoints()
+	if err != nil || len(cps) != 2 {
+		t.Fatalf("expected 2 checkpoints, got %v, %v", cps, err)
+	}
+	if cps[0].ID != 0 || cps[0].Name != "" || cps[1].ID != 1 || cps[1].Name != "initial" || cps[1].Comment != "before change" {
+		t.Errorf("unexpected checkpoints: %+v", cps)
+	}
+	if cps[1].Username != s.Username || cps[1].Version != c.GetSysVer() || time.Since(cps[1].Created) > time.Minute {
+		t.Errorf("unexpected checkpoint metadata: %+v", cps[1])
+	}
+
+	// Revert to checkpoint 1
+	if _, err := c.RevertCheckpoint(1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v, err := s.Get("running", descPath); err != nil || v != "INITIAL" {
+		t.Errorf("expected INITIAL after revert, got %v, %v", v, err)
+	}
+
+	// Clear checkpoint 0, so checkpoint 1 becomes 0
+	if _, err := c.ClearCheckpoint(0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cps, err = c.ListCheckpoints()
+	if err != nil || len(cps) != 1 || cps[0].ID != 0 || cps[0].Name != "initial" {
+		t.Errorf("unexpected checkpoints after clear: %+v, %v", cps, err)
+	}
+
+	// Unknown checkpoint
+	if _, err := c.RevertCheckpoint(5); !errors.Is(err, apierr.ErrClntJSONRPCResp) {
+		t.Errorf("expected error %v, got %v", apierr.ErrClntJSONRPCResp, err)
+	}
+}
@@ -0,0 +1,193 @@
+// Package clab provides loader of containerlab topology files to build JSON RPC clients for SR Linux nodes.
+package clab
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/azyablov/srljrpc"
+	"github.com/azyablov/srljrpc/apierr"
+	"gopkg.in/yaml.v3"
+)
+
+// Default containerlab prefix of the container names.
+const defPrefix = "clab"
+
+// Special prefix value to use lab name only as a prefix.
+const labNamePrefix = "__lab-name"
+
+// Kinds of SR Linux nodes in containerlab topology.
+var srlKinds = map[string]bool{"srl": true, "nokia_srlinux": true}
+
+// Node type to represent containerlab node definition, also used for defaults and kinds sections.
+type Node struct {
+	Kind     string `yaml:"kind,omitempty"`
+	Type     string `yaml:"type,omitempty"`
+	Image    string `yaml:"image,omitempty"`
+	Group    string `yaml:"group,omitempty"`
+	MgmtIPv4 string `yaml:"mgmt-ipv4,omitempty"`
+	MgmtIPv6 string `yaml:"mgmt-ipv6,omitempty"`
+}
+
+// Topology type to represent containerlab topology file.
+type Topology struct {
+	Name     string  `yaml:"name"`
+	Prefix   *string `yaml:"prefix,omitempty"`
+	Topology struct {
+		Defaults Node            `yaml:"defaults,omitempty"`
+		Kinds    map[string]Node `yaml:"kinds,omitempty"`
+		Groups   map[string]Node `yaml:"groups,omitempty"`
+		Nodes    map[string]Node `yaml:"nodes"`
+	} `yaml:"topology"`
+	dir string // directory of the topology file, lab directory is created by containerlab inside
+}
+
+// SRLNode type to represent SR Linux node of the topology with derived hostname and generated TLS files.
+type SRLNode struct {
+	Name     string // node name as specified in topology
+	Kind     string // node kind
+	Type     string // node type, e.g. ixrd2
+	Host     string // container hostname, e.g. clab-evpn-leaf1
+	CAFile   string // CA certificate generated by containerlab
+	CertFile string // node certificate generated by containerlab
+	KeyFile  string // node private key generated by containerlab
+}
+
+// Load reads and parses containerlab topology file.
+func Load(path string) (*Topology, error) {
+	fh, err := os.Open(path)
+	if err != nil {
+		return nil, apierr.NewClientError(apierr.CodeClntClabRead, err)
+	}
+	defer fh.Close()
+	return Decode(fh, filepath.Dir(path))
+}
+
+// Decode parses containerlab topology from reader, dir is the directory of the topology file used to derive lab directory.
+func Decode(r io.Reader, dir string) (*Topology, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, apierr.NewClientError(apierr.CodeClntClabRead, err)
+	}
+	t := &Topology{dir: dir}
+	if err := yaml.Unmarshal(b, t); err != nil {
+		return nil, apierr.NewClientError(apierr.CodeClntClabParse, err)
+	}
+	if t.Name == "" {
+		return nil, apierr.NewClientError(apierr.CodeClntClabParse, nil)
+	}
+	return t, nil
+}
+
+// LabDir returns lab directory created by containerlab next to topology file.
+func (t *Topology) LabDir() string {
+	return filepath.Join(t.dir, "clab-"+t.Name)
+}
+
+// Hostname returns container hostname of the node in accordance with topology prefix:
+// clab-<lab>-<node> by default, <node> for empty prefix, <lab>-<node> for __lab-name prefix and <prefix>-<lab>-<node> otherwise.
+func (t *Topology) Hostname(node string) string {
+	p := defPrefix
+	if t.Prefix != nil {
+		p = *t.Prefix
+	}
+	switch p {
+	case "":
+		return node
+	case labNamePrefix:
+		return t.Name + "-" + node
+	default:
+		return p + "-" + t.Name + "-" + node
+	}
+}
+
+// Helper method resolving node kind using node, group and defaults definitions.
+func (t *Topology) kind(n Node) string {
+	if n.Kind != "" {
+		return n.Kind
+	}
+	if g, ok := t.Topology.Groups[n.Group]; ok && g.Kind != "" {
+		return g.Kind
+	}
+	return t.Topology.Defaults.Kind
+}
+
+// SRLNodes returns SR Linux nodes of the topology sorted by name.
+func (t *Topology) SRLNodes() []SRLNode {
+	var nodes []SRLNode
+	tlsDir := filepath.Join(t.LabDir(), ".tls")
+	for name, n := range t.Topology.Nodes {
+		k := t.kind(n)
+		if !srlKinds[k] {
+			continue
+		}
+		nodeType := n.Type
+		if nodeType == "" {
+			nodeType = t.Topology.Kinds[k].Type
+		}
+		nodes = append(nodes, SRLNode{
+			Name:     name,
+			Kind:     k,
+			Type:     nodeType,
+			Host:     t.Hostname(name),
+			CAFile:   filepath.Join(tlsDir, "ca", "ca.pem"),
+			CertFile: filepath.Join(tlsDir, name, name+".pem"),
+			KeyFile:  filepath.Join(tlsDir, name, name+".key"),
+		})
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Name < nodes[j].Name })
+	return nodes
+}
+
+// TLSAttr returns TLS attributes with containerlab generated CA, node certificate and key, which enforces server certificate verification.
+func (n *SRLNode) TLSAttr() *srljrpc.TLSAttr {
+	skipVerify := false
+	ca, cert, key := n.CAFile, n.CertFile, n.KeyFile
+	return &srljrpc.TLSAttr{
+		CAFile:     &ca,
+		CertFile:   &cert,
+		KeyFile:    &key,
+		SkipVerify: &skipVerify,
+	}
+}
+
+// ClientOptions returns ClientOptions for the node: TLS attributes with generated files if verifyTLS is true, otherwise verification is skipped.
+// Additional options (e.g. credentials) are appended and applied after the node options.
+func (n *SRLNode) ClientOptions(verifyTLS bool, opts ...srljrpc.ClientOption) []srljrpc.ClientOption {
+	var nodeOpts []srljrpc.ClientOption
+	if verifyTLS {
+		nodeOpts = append(nodeOpts, srljrpc.WithOptTLS(n.TLSAttr()))
+	}
+	return append(nodeOpts, opts...)
+}
+
+// NewClient creates a new JSON RPC client for the node.
+func (n *SRLNode) NewClient(ctx context.Context, verifyTLS bool, opts ...srljrpc.ClientOption) (*srljrpc.JSONRPCClient, error) {
+	host := n.Host
+	return srljrpc.NewJSONRPCClientContext(ctx, &host, n.ClientOptions(verifyTLS, opts...)...)
+}
+
+// Targets returns fleet targets for all SR Linux nodes of the topology.
+func (t *Topology) Targets(verifyTLS bool, opts ...srljrpc.ClientOption) ([]srljrpc.FleetTarget, error) {
+	nodes := t.SRLNodes()
+	if len(nodes) == 0 {
+		return nil, apierr.NewClientError(apierr.CodeClntClabNoSRLNodes, nil)
+	}
+	var targets []srljrpc.FleetTarget
+	for i := range nodes {
+		targets = append(targets, srljrpc.FleetTarget{Host: nodes[i].Host, Opts: nodes[i].ClientOptions(verifyTLS, opts...)})
+	}
+	return targets, nil
+}
+
+// Fleet creates a fleet of clients for all SR Linux nodes of the topology, see srljrpc.NewFleetFromTargets() for details.
+func (t *Topology) Fleet(ctx context.Context, verifyTLS bool, fopts []srljrpc.FleetOption, opts ...srljrpc.ClientOption) (*srljrpc.Fleet, *srljrpc.FleetReport, error) {
+	targets, err := t.Targets(verifyTLS, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return srljrpc.NewFleetFromTargets(ctx, targets, fopts...)
+}
@@ -0,0 +1,97 @@
+//go:build unit
+
+package srljrpc_test
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/azyablov/srljrpc/apierr"
+	"github.com/azyablov/srljrpc/clab"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestClabLoad(t *testing.T) {
+	topo, err := clab.Load("_clab/nokia-evpn.clab.yml")
+	if err != nil {
+		t.Fatalf("can't load topology: %v", err)
+	}
+	nodes := topo.SRLNodes()
+	var names, hosts []string
+	for _, n := range nodes {
+		names = append(names, n.Name)
+		hosts = append(hosts, n.Host)
+	}
+	if out := cmp.Diff([]string{"leaf1", "leaf2", "spine3", "spine4"}, names); out != "" {
+		t.Errorf("unexpected SRL nodes (-want +got):\n%s", out)
+	}
+	if out := cmp.Diff([]string{"clab-evpn-leaf1", "clab-evpn-leaf2", "clab-evpn-spine3", "clab-evpn-spine4"}, hosts); out != "" {
+		t.Errorf("unexpected hosts (-want +got):\n%s", out)
+	}
+	// Paths must be the same as used by integration tests.
+	exp := clab.SRLNode{Name: "spine4", Kind: "srl", Type: "ixr6e", Host: "clab-evpn-spine4",
+		CAFile:   filepath.Join("_clab", "clab-evpn", ".tls", "ca", "ca.pem"),
+		CertFile: filepath.Join("_clab", "clab-evpn", ".tls", "spine4", "spine4.pem"),
+		KeyFile:  filepath.Join("_clab", "clab-evpn", ".tls", "spine4", "spine4.key"),
+	}
+	if out := cmp.Diff(exp, nodes[3]); out != "" {
+		t.Errorf("unexpected node (-want +got):\n%s", out)
+	}
+	targets, err := topo.Targets(true)
+	if err != nil || len(targets) != 4 || len(targets[0].Opts) != 1 {
+		t.Errorf("unexpected targets: %v, %v", targets, err)
+	}
+}
+
+func TestClabDecode(t *testing.T) {
+	var testData = []struct {
+		testName string
+		topo     string
+		expHosts []string
+		expErr   error
+	}{
+		{testName: "Empty prefix and default kind",
+			topo:     "name: lab\nprefix: \"\"\ntopology:\n  defaults:\n    kind: nokia_srlinux\n  nodes:\n    srl1: {}\n    host1:\n      kind: linux\n",
+			expHosts: []string{"srl1"}},
+		{testName: "Lab name prefix and group kind",
+			topo:     "name: lab\nprefix: __lab-name\ntopology:\n  groups:\n    spines:\n      kind: srl\n  nodes:\n    s1:\n      group: spines\n",
+			expHosts: []string{"lab-s1"}},
+		{testName: "Custom prefix",
+			topo:     "name: lab\nprefix: dc\ntopology:\n  nodes:\n    l1:\n      kind: srl\n",
+			expHosts: []string{"dc-lab-l1"}},
+		{testName: "Missing lab name",
+			topo:   "topology:\n  nodes:\n    l1:\n      kind: srl\n",
+			expErr: apierr.ErrClntClabParse},
+		{testName: "Invalid YAML",
+			topo:   "name: [lab",
+			expErr: apierr.ErrClntClabParse},
+	}
+	for _, td := range testData {
+		t.Run(td.testName, func(t *testing.T) {
+			topo, err := clab.Decode(strings.NewReader(td.topo), ".")
+			if td.expErr != nil {
+				if !errors.Is(err, td.expErr) {
+					t.Fatalf("expected error %v, got %v", td.expErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var hosts []string
+			for _, n := range topo.SRLNodes() {
+				hosts = append(hosts, n.Host)
+			}
+			if out := cmp.Diff(td.expHosts, hosts); out != "" {
+				t.Errorf("unexpected hosts (-want +got):\n%s", out)
+			}
+		})
+	}
+
+	topo, _ := clab.Decode(strings.NewReader("name: lab\ntopology:\n  nodes:\n    h1:\n      kind: linux\n"), ".")
+	if _, err := topo.Targets(false); !errors.Is(err, apierr.ErrClntClabNoSRLNodes) {
+		t.Errorf("expected error %v, got %v", apierr.ErrClntClabNoSRLNodes, err)
+	}
+}
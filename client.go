@@ -2,15 +2,18 @@ package srljrpc
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
 	"crypto/x509"
-	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"os"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/azyablov/srljrpc/actions"
@@ -38,6 +41,10 @@ type targetHost struct {
 	host    *string
 	port    *int
 	timeout time.Duration
+	scheme  string
+	path    string
+	socket  string
+	url     string // built from the fields above by populateDefaults
 }
 
 // JSONRPCTarget type to represent a JSON RPC target: NE(target), TLS attributes, credentials.
@@ -49,11 +56,30 @@ type JSONRPCTarget struct {
 
 // JSONRPCClient type to represent a JSON RPC client: HTTP client, NE(target) and related info.
 type JSONRPCClient struct {
-	client   *http.Client
-	hostname string
-	sysVer   string
-	target   *JSONRPCTarget
-	mux      sync.Mutex
+	client      *http.Client
+	infoMu      sync.RWMutex // guards hostname, sysVer and release updated by verification
+	hostname    string
+	sysVer      string
+	release     *Version // parsed sysVer, nil if can't be parsed
+	lazy        bool
+	verifyMu    sync.Mutex  // serializes verification
+	unverified  atomic.Bool // verification is deferred or the target must be re-verified
+	caps        Capabilities
+	target      *JSONRPCTarget
+	retry       *RetryPolicy
+	mux         sync.Mutex // serializes BulkSetCallBack calls
+	auth        Authenticator
+	transport   http.RoundTripper // replaces default HTTP transport, e.g. ReplayTransport
+	recorder    *recorder
+	middlewares []Middleware
+	handler     Handler // chain of middlewares around do
+	logger      *slog.Logger
+	logOpts     *logOpts
+	metrics     Metrics
+	tracer      Tracer
+	factsMu     sync.Mutex
+	facts       *DeviceFacts
+	inFlight    chan struct{} // semaphore limiting in-flight asynchronous requests
 }
 
 // PV type to represent a path-value pair.
@@ -62,6 +88,16 @@ type PV struct {
 	Value CommandValue `json:"value"`
 }
 
+// HTTPStatusError type to represent unexpected HTTP status returned by the target, wrapped into ClientError with code CodeClntHTTPStatus.
+type HTTPStatusError struct {
+	StatusCode int
+	Status     string
+}
+
+func (e *HTTPStatusError) Error() string {
+	return fmt.Sprintf("HTTP status: %s", e.Status)
+}
+
 // ClientOption is a function type that applies options to a JSONRPCClient object.
 type ClientOption func(*JSONRPCClient) error
 
@@ -71,6 +107,12 @@ type CallBackConfirm func(req *Request, resp *Response) (bool, error)
 
 // Creates a new JSON RPC client and applies options in order of appearance.
 func NewJSONRPCClient(host *string, opts ...ClientOption) (*JSONRPCClient, error) {
+	return NewJSONRPCClientContext(context.Background(), host, opts...)
+}
+
+// Creates a new JSON RPC client and applies options in order of appearance.
+// The provided context is used for target verification and must be non-nil.
+func NewJSONRPCClientContext(ctx context.Context, host *string, opts ...ClientOption) (*JSONRPCClient, error) {
 	// client object
 	c := &JSONRPCClient{}
 	c.target = &JSONRPCTarget{}
@@ -78,7 +120,12 @@ func NewJSONRPCClient(host *string, opts ...ClientOption) (*JSONRPCClient, error
 	if host == nil {
 		return nil, apierr.NewClientError(apierr.CodeClntNoHost, nil)
 	}
-	c.target.host = host
+	// IPv6 literal could be provided in brackets
+	h := *host
+	if strings.HasPrefix(h, "[") && strings.HasSuffix(h, "]") {
+		h = h[1 : len(h)-1]
+	}
+	c.target.host = &h
 
 	// applying options
 	for _, opt := range opts {
@@ -94,22 +141,44 @@ func NewJSONRPCClient(host *string, opts ...ClientOption) (*JSONRPCClient, error
 		return nil, err
 	}
 
-	// ... creating a new HTTP client
-	c.client = &http.Client{
-		Transport: &http.Transport{
-			MaxIdleConns:          32,
-			IdleConnTimeout:       90 * time.Second,
-			TLSHandshakeTimeout:   10 * time.Second,
-			ExpectContinueTimeout: 1 * time.Second,
-			TLSClientConfig:       c.target.tlsConfig,
-		},
-		Timeout: c.target.timeout,
+	// ... creating a new HTTP client, unless provided by WithOptHTTPClient
+	if c.client == nil {
+		rt := c.transport
+		if rt == nil {
+			t := &http.Transport{
+				MaxIdleConns:          32,
+				IdleConnTimeout:       90 * time.Second,
+				TLSHandshakeTimeout:   10 * time.Second,
+				ExpectContinueTimeout: 1 * time.Second,
+				TLSClientConfig:       c.target.tlsConfig,
+			}
+			if c.target.socket != "" {
+				t.DialContext = c.dialSocket
+			}
+			rt = t
+		}
+		c.client = &http.Client{
+			Transport: rt,
+			Timeout:   c.target.timeout,
+		}
 	}
+	if c.recorder != nil {
+		// copy to keep the provided HTTP client intact
+		hc := *c.client
+		c.recorder.next = hc.Transport
+		if c.recorder.next == nil {
+			c.recorder.next = http.DefaultTransport
+		}
+		hc.Transport = c.recorder
+		c.client = &hc
+	}
+	c.buildHandler()
 
-	// verify target validity and availability
-	err = c.targetVerification()
-	if err != nil {
-		return nil, apierr.NewClientError(apierr.CodeClntTargetVerification, err)
+	// verify target validity and availability, unless deferred until the first request
+	if c.lazy {
+		c.unverified.Store(true)
+	} else if err := c.VerifyContext(ctx); err != nil {
+		return nil, err
 	}
 
 	return c, nil
@@ -117,68 +186,145 @@ func NewJSONRPCClient(host *string, opts ...ClientOption) (*JSONRPCClient, error
 
 // GetSysVer returns the system version of the target after verification.
 func (c *JSONRPCClient) GetSysVer() string {
+	c.infoMu.RLock()
+	defer c.infoMu.RUnlock()
 	return c.sysVer
 }
 
 // GetHostname returns the hostname of the target after verification.
 func (c *JSONRPCClient) GetHostname() string {
+	c.infoMu.RLock()
+	defer c.infoMu.RUnlock()
 	return c.hostname
 }
 
 // Calls the JSON RPC server and returns the response.
 func (c *JSONRPCClient) Do(r Requester) (*Response, error) {
+	return c.DoContext(context.Background(), r)
+}
+
+// Calls the JSON RPC server and returns the response.
+// The provided context must be non-nil, cancellation and deadline of the context are propagated to the HTTP request.
+// Transient failures are retried in accordance with RetryPolicy if set by WithOptRetry().
+// The target is verified before the request if verification was deferred by WithOptLazyVerification() or the previous request failed to reach the target.
+func (c *JSONRPCClient) DoContext(ctx context.Context, r Requester) (*Response, error) {
+	if err := c.ensureVerified(ctx); err != nil {
+		return nil, err
+	}
+	return c.doContext(ctx, r)
+}
+
+// Calls the JSON RPC server w/o verification of the target, facilitates DoContext and targetVerification.
+func (c *JSONRPCClient) doContext(ctx context.Context, r Requester) (*Response, error) {
+	ctx, span := c.startRequestSpan(ctx, r)
+	if err := c.checkCapabilities(r); err != nil {
+		endSpan(span, nil, err)
+		return nil, err
+	}
+	rs := []Requester{r}
+	if br, ok := r.(*batchRequest); ok {
+		rs = br.b.requests
+	}
+	var resp *Response
+	err := c.withRetry(ctx, rs, func() error {
+		var err error
+		resp, err = c.handler(ctx, r)
+		return err
+	})
+	c.reverifyOn(err)
+	endSpan(span, resp, err)
+	return resp, err
+}
+
+// Single attempt to call the JSON RPC server, facilitates DoContext.
+func (c *JSONRPCClient) do(ctx context.Context, r Requester) (*Response, error) {
+	if br, ok := r.(*batchRequest); ok {
+		return nil, c.doBatch(ctx, br)
+	}
 	body, err := r.Marshal()
 	if err != nil {
 		return nil, apierr.NewClientError(apierr.CodeClntReqMarshalling, err)
 	}
 
-	reqHTTP, err := http.NewRequest("POST", fmt.Sprintf("https://%s:%v/jsonrpc", *c.target.host, *c.target.port), bytes.NewBuffer(body))
+	rpcResp := Response{}
+	if err := c.send(ctx, body, &rpcResp); err != nil {
+		return nil, err
+	}
+	if rpcResp.GetID() != r.GetID() {
+		return nil, apierr.NewClientError(apierr.CodeClntIDMismatch, nil)
+	}
+
+	if rpcResp.Error != nil {
+		return &rpcResp, apierr.NewClientError(apierr.CodeClntJSONRPCResp, rpcResp.Error.jsonRPCError())
+	}
+
+	return &rpcResp, nil
+}
+
+// Sends HTTP request with the body to the JSON RPC server and decodes response body into v.
+func (c *JSONRPCClient) send(ctx context.Context, body []byte, v interface{}) error {
+	reqHTTP, err := http.NewRequestWithContext(ctx, "POST", c.target.url, bytes.NewBuffer(body))
 	if err != nil {
-		return nil, apierr.NewClientError(apierr.CodeClntHTTPReqCreation, err)
+		return apierr.NewClientError(apierr.CodeClntHTTPReqCreation, err)
 	}
 
 	// setting content type and authentication header
 	reqHTTP.Header.Set("Content-Type", "application/json")
-	reqHTTP.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s:%s", *c.target.username, *c.target.password))))
+	if err := c.auth.Authenticate(ctx, reqHTTP); err != nil {
+		return apierr.NewClientError(apierr.CodeClntAuth, err)
+	}
+
+	// statistics collected for Metrics
+	st, _ := ctx.Value(exchangeStatsKey{}).(*exchangeStats)
+	if st != nil {
+		st.sent = int64(len(body))
+	}
 
 	resp, err := c.client.Do(reqHTTP)
 	if err != nil {
-		return nil, apierr.NewClientError(apierr.CodeClntHTTPSend, err)
+		if ctx.Err() != nil {
+			return apierr.NewClientError(apierr.CodeClntCtxDone, err)
+		}
+		return apierr.NewClientError(apierr.CodeClntHTTPSend, err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, apierr.NewClientError(apierr.CodeClntHTTPStatus, fmt.Errorf("HTTP status: %s", resp.Status))
+		return apierr.NewClientError(apierr.CodeClntHTTPStatus, &HTTPStatusError{StatusCode: resp.StatusCode, Status: resp.Status})
 	}
 
-	rpcResp := Response{}
-	err = json.NewDecoder(resp.Body).Decode(&rpcResp)
-	if err != nil {
-		return nil, apierr.NewClientError(apierr.CodeClntRespJSONUnmarshalling, err)
-	}
-	if rpcResp.GetID() != r.GetID() {
-		return nil, apierr.NewClientError(apierr.CodeClntIDMismatch, nil)
+	var rb io.Reader = resp.Body
+	if st != nil {
+		rb = countingReader{r: resp.Body, n: &st.received}
 	}
-
-	if rpcResp.Error != nil {
-		return &rpcResp, apierr.NewClientError(apierr.CodeClntJSONRPCResp, nil)
+	if err = json.NewDecoder(rb).Decode(v); err != nil {
+		return apierr.NewClientError(apierr.CodeClntRespJSONUnmarshalling, err)
 	}
-
-	return &rpcResp, nil
+	return nil
 }
 
 // Get method of JSONRPCClient. Executes a GET request against RUNNING datastore.
 func (c *JSONRPCClient) Get(paths ...string) (*Response, error) {
-	return c.get(datastores.RUNNING, paths...)
+	return c.GetContext(context.Background(), paths...)
+}
+
+// GetContext is the same as Get, but uses the provided context for the request.
+func (c *JSONRPCClient) GetContext(ctx context.Context, paths ...string) (*Response, error) {
+	return c.get(ctx, datastores.RUNNING, paths...)
 }
 
 // Get state method of JSONRPCClient. Executes a GET request against STATE datastore.
 func (c *JSONRPCClient) State(paths ...string) (*Response, error) {
-	return c.get(datastores.STATE, paths...)
+	return c.StateContext(context.Background(), paths...)
+}
+
+// StateContext is the same as State, but uses the provided context for the request.
+func (c *JSONRPCClient) StateContext(ctx context.Context, paths ...string) (*Response, error) {
+	return c.get(ctx, datastores.STATE, paths...)
 }
 
 // Generic get method of JSONRPCClient. Executes a GET request against specified datastore, facilitates Get and State methods.
-func (c *JSONRPCClient) get(ds datastores.EnumDatastores, paths ...string) (*Response, error) {
+func (c *JSONRPCClient) get(ctx context.Context, ds datastores.EnumDatastores, paths ...string) (*Response, error) {
 	var opts []CommandOption
 	switch ds {
 	case datastores.RUNNING:
@@ -200,13 +346,18 @@ func (c *JSONRPCClient) get(ds datastores.EnumDatastores, paths ...string) (*Res
 	if err != nil {
 		return nil, apierr.NewClientError(apierr.CodeClntRPCReqCreation, err)
 	}
-	return c.Do(r)
+	return c.DoContext(ctx, r)
 }
 
 // SetUpdate method of JSONRPCClient executing a SET/UPDATE action request against CANDIDATE datastore.
 // ct is the timeout in seconds for the confirm operation, set to 0 to disable.
 // pvs is the list of path-value pairs. Yang model type is default(SRL).
 func (c *JSONRPCClient) Update(ct int, pvs ...PV) (*Response, error) {
+	return c.UpdateContext(context.Background(), ct, pvs...)
+}
+
+// UpdateContext is the same as Update, but uses the provided context for the request.
+func (c *JSONRPCClient) UpdateContext(ctx context.Context, ct int, pvs ...PV) (*Response, error) {
 	var cmds []*Command
 	for _, pv := range pvs {
 		cmd, err := NewCommand(actions.UPDATE, pv.Path, CommandValue(pv.Value))
@@ -228,13 +379,18 @@ func (c *JSONRPCClient) Update(ct int, pvs ...PV) (*Response, error) {
 	if err != nil {
 		return nil, apierr.NewClientError(apierr.CodeClntRPCReqCreation, err)
 	}
-	return c.Do(r)
+	return c.DoContext(ctx, r)
 }
 
 // SetReplace method of JSONRPCClient. Executes a SET/REPLACE action request against CANDIDATE datastore.
 // ct is the timeout in seconds for the confirm operation, set to 0 to disable.
 // pvs is the list of path-value pairs. Yang model type is default(SRL).
 func (c *JSONRPCClient) Replace(ct int, pvs ...PV) (*Response, error) {
+	return c.ReplaceContext(context.Background(), ct, pvs...)
+}
+
+// ReplaceContext is the same as Replace, but uses the provided context for the request.
+func (c *JSONRPCClient) ReplaceContext(ctx context.Context, ct int, pvs ...PV) (*Response, error) {
 	var cmds []*Command
 	for _, pv := range pvs {
 		cmd, err := NewCommand(actions.REPLACE, pv.Path, pv.Value)
@@ -256,13 +412,18 @@ func (c *JSONRPCClient) Replace(ct int, pvs ...PV) (*Response, error) {
 	if err != nil {
 		return nil, apierr.NewClientError(apierr.CodeClntRPCReqCreation, err)
 	}
-	return c.Do(r)
+	return c.DoContext(ctx, r)
 }
 
 // SetDelete method of JSONRPCClient. Executes a SET/DELETE action request against CANDIDATE datastore.
 // t is the timeout in seconds for the confirm operation, set to 0 to disable.
 // paths is the list of path to delete. Yang model type is default(SRL).
 func (c *JSONRPCClient) Delete(ct int, paths ...string) (*Response, error) {
+	return c.DeleteContext(context.Background(), ct, paths...)
+}
+
+// DeleteContext is the same as Delete, but uses the provided context for the request.
+func (c *JSONRPCClient) DeleteContext(ctx context.Context, ct int, paths ...string) (*Response, error) {
 	// build the commands
 	var cmds []*Command
 	for _, path := range paths {
@@ -285,12 +446,17 @@ func (c *JSONRPCClient) Delete(ct int, paths ...string) (*Response, error) {
 	if err != nil {
 		return nil, apierr.NewClientError(apierr.CodeClntRPCReqCreation, err)
 	}
-	return c.Do(r)
+	return c.DoContext(ctx, r)
 }
 
 // DiffCandidate method of JSONRPCClient. Executes a DIFF/<action> action request against CANDIDATE datastore. Yang model type is default(SRL).
 // pvs are path-value pairs. The action parameter must be one of DELETE, REPLACE, or UPDATE.
 func (c *JSONRPCClient) DiffCandidate(action actions.EnumActions, ym yms.EnumYmType, pvs ...PV) (*Response, error) {
+	return c.DiffCandidateContext(context.Background(), action, ym, pvs...)
+}
+
+// DiffCandidateContext is the same as DiffCandidate, but uses the provided context for the request.
+func (c *JSONRPCClient) DiffCandidateContext(ctx context.Context, action actions.EnumActions, ym yms.EnumYmType, pvs ...PV) (*Response, error) {
 	var delete, replace, update []PV
 	// identify the action
 	switch action {
@@ -309,19 +475,24 @@ func (c *JSONRPCClient) DiffCandidate(action actions.EnumActions, ym yms.EnumYmT
 	if err != nil {
 		return nil, apierr.NewClientError(apierr.CodeClntRPCReqCreation, err)
 	}
-	return c.Do(r)
+	return c.DoContext(ctx, r)
 }
 
 // Bulk CRUD method of JSONRPCClient. Executes a SET method with REPLACE/UPDATE/DELETE action request against CANDIDATE datastore.
 // ct is the timeout in seconds for the confirm operation, set to 0 to disable. delete/replace/update are path-value pairs.
 // All the PVs are applied immediately in the same order as they are provided. yang model type is mandatory for diff to specify: SRL or OC.
 func (c *JSONRPCClient) BulkSet(delete []PV, replace []PV, update []PV, ym yms.EnumYmType, ct int) (*Response, error) {
+	return c.BulkSetContext(context.Background(), delete, replace, update, ym, ct)
+}
+
+// BulkSetContext is the same as BulkSet, but uses the provided context for the request.
+func (c *JSONRPCClient) BulkSetContext(ctx context.Context, delete []PV, replace []PV, update []PV, ym yms.EnumYmType, ct int) (*Response, error) {
 	// build the request
 	r, err := NewSetRequest(delete, replace, update, ym, formats.JSON, datastores.CANDIDATE, ct)
 	if err != nil {
 		return nil, apierr.NewClientError(apierr.CodeClntRPCReqCreation, err)
 	}
-	return c.Do(r)
+	return c.DoContext(ctx, r)
 }
 
 // Bulk CRUD method of JSONRPCClient w/ CallBackConfirm callback and mandatory confirm timeout.
@@ -329,6 +500,12 @@ func (c *JSONRPCClient) BulkSet(delete []PV, replace []PV, update []PV, ym yms.E
 // All the PVs are applied immediately in the same order as they are provided. yang model type is mandatory for diff to specify: SRL or OC.
 // JSON RPC Response is not nil if the callback function returns true. if callback function returns false, the both response&error are nil to indicate changes rolled back and NE back to previous state.
 func (c *JSONRPCClient) BulkSetCallBack(delete []PV, replace []PV, update []PV, ym yms.EnumYmType, ct int, cbt int, cbf CallBackConfirm) (*Response, error) {
+	return c.BulkSetCallBackContext(context.Background(), delete, replace, update, ym, ct, cbt, cbf)
+}
+
+// BulkSetCallBackContext is the same as BulkSetCallBack, but uses the provided context for the requests and the wait before the callback execution.
+// If the context is done while waiting, the changes are not confirmed and will be rolled back by NE after confirm timeout expiration.
+func (c *JSONRPCClient) BulkSetCallBackContext(ctx context.Context, delete []PV, replace []PV, update []PV, ym yms.EnumYmType, ct int, cbt int, cbf CallBackConfirm) (*Response, error) {
 	// check cbt is lower than ct anc ct > 0 (mandatory)
 	if ct <= cbt+1 && ct > 0 { // +1 to avoid 0
 		return nil, apierr.NewClientError(apierr.CodeClntCBFuncLowerThanCT, nil)
@@ -337,6 +514,14 @@ func (c *JSONRPCClient) BulkSetCallBack(delete []PV, replace []PV, update []PV,
 	if cbf == nil {
 		return nil, apierr.NewClientError(apierr.CodeClntCBFuncIsNil, nil)
 	}
+	ctx, span := c.startSpan(ctx, "srljrpc.BulkSetCallBack")
+	resp, err := c.bulkSetCallBack(ctx, delete, replace, update, ym, ct, cbt, cbf)
+	endSpan(span, nil, err)
+	return resp, err
+}
+
+// Helper method executing BulkSetCallBackContext steps in child spans.
+func (c *JSONRPCClient) bulkSetCallBack(ctx context.Context, delete []PV, replace []PV, update []PV, ym yms.EnumYmType, ct int, cbt int, cbf CallBackConfirm) (*Response, error) {
 	// build the request
 	req, err := NewSetRequest(delete, replace, update, ym, formats.JSON, datastores.CANDIDATE, ct)
 	if err != nil {
@@ -345,22 +530,41 @@ func (c *JSONRPCClient) BulkSetCallBack(delete []PV, replace []PV, update []PV,
 	// execute the request
 	c.mux.Lock()
 	defer c.mux.Unlock()
-	resp, err := c.Do(req)
+	sctx, span := c.startSpan(ctx, "srljrpc.BulkSetCallBack.set")
+	resp, err := c.DoContext(sctx, req)
+	endSpan(span, nil, err)
 	if err != nil {
 		return resp, err
 	}
 
 	if ct-cbt > 2 {
-		tch := time.After(time.Duration(cbt) * time.Second)
-		<-tch
+		_, span = c.startSpan(ctx, "srljrpc.BulkSetCallBack.wait")
+		t := time.NewTimer(time.Duration(cbt) * time.Second)
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			t.Stop()
+			err := apierr.NewClientError(apierr.CodeClntCtxDone, ctx.Err())
+			endSpan(span, nil, err)
+			return nil, err
+		}
+		span.End(nil)
 	}
 	// execute the callback
+	_, span = c.startSpan(ctx, "srljrpc.BulkSetCallBack.callback")
 	confirm, err := cbf(req, resp)
 	if err != nil {
-		return nil, apierr.NewClientError(apierr.CodeClntCBFuncExec, err)
+		err = apierr.NewClientError(apierr.CodeClntCBFuncExec, err)
+	}
+	span.SetAttributes(SpanAttribute{"srljrpc.confirm", confirm})
+	endSpan(span, nil, err)
+	if err != nil {
+		return nil, err
 	}
 	if confirm {
-		_, err := c.Tools(PV{Path: "/system/configuration/confirmed-accept", Value: CommandValue("")})
+		actx, span := c.startSpan(ctx, "srljrpc.BulkSetCallBack.confirm-accept")
+		_, err := c.ToolsContext(actx, PV{Path: confirmedAcceptPath, Value: CommandValue("")})
+		endSpan(span, nil, err)
 		if err != nil {
 			return nil, err
 		}
@@ -372,16 +576,26 @@ func (c *JSONRPCClient) BulkSetCallBack(delete []PV, replace []PV, update []PV,
 // Bulk CRUD method of JSONRPCClient. Executes a DIFF method with REPLACE/UPDATE/DELETE action request against CANDIDATE datastore.
 // delete/replace/update are path-value pairs. yang model type is mandatory for diff to specify: SRL or OC.
 func (c *JSONRPCClient) BulkDiff(delete []PV, replace []PV, update []PV, ym yms.EnumYmType) (*Response, error) {
+	return c.BulkDiffContext(context.Background(), delete, replace, update, ym)
+}
+
+// BulkDiffContext is the same as BulkDiff, but uses the provided context for the request.
+func (c *JSONRPCClient) BulkDiffContext(ctx context.Context, delete []PV, replace []PV, update []PV, ym yms.EnumYmType) (*Response, error) {
 	// build the request
 	r, err := NewDiffRequest(delete, replace, update, ym, formats.JSON, datastores.CANDIDATE)
 	if err != nil {
 		return nil, apierr.NewClientError(apierr.CodeClntRPCReqCreation, err)
 	}
-	return c.Do(r)
+	return c.DoContext(ctx, r)
 }
 
 // Validate() action of the method SET. Executes a SET/VALIDATE specified action request against CANDIDATE datastore. Yang model type is default(SRL).
 func (c *JSONRPCClient) Validate(action actions.EnumActions, pvs ...PV) (*Response, error) {
+	return c.ValidateContext(context.Background(), action, pvs...)
+}
+
+// ValidateContext is the same as Validate, but uses the provided context for the request.
+func (c *JSONRPCClient) ValidateContext(ctx context.Context, action actions.EnumActions, pvs ...PV) (*Response, error) {
 	var cmds []*Command
 	for _, pv := range pvs {
 		cmd, err := NewCommand(action, pv.Path, pv.Value)
@@ -395,11 +609,16 @@ func (c *JSONRPCClient) Validate(action actions.EnumActions, pvs ...PV) (*Respon
 	if err != nil {
 		return nil, apierr.NewClientError(apierr.CodeClntRPCReqCreation, err)
 	}
-	return c.Do(r)
+	return c.DoContext(ctx, r)
 }
 
 // Tools() action of the method SET. Executes a SET/UPDATE action request against TOOLS datastore. Yang model type is default(SRL).
 func (c *JSONRPCClient) Tools(pvs ...PV) (*Response, error) {
+	return c.ToolsContext(context.Background(), pvs...)
+}
+
+// ToolsContext is the same as Tools, but uses the provided context for the request.
+func (c *JSONRPCClient) ToolsContext(ctx context.Context, pvs ...PV) (*Response, error) {
 	var cmds []*Command
 	for _, pv := range pvs {
 		cmd, err := NewCommand(actions.UPDATE, pv.Path, CommandValue(pv.Value))
@@ -413,16 +632,21 @@ func (c *JSONRPCClient) Tools(pvs ...PV) (*Response, error) {
 	if err != nil {
 		return nil, apierr.NewClientError(apierr.CodeClntRPCReqCreation, err)
 	}
-	return c.Do(r)
+	return c.DoContext(ctx, r)
 }
 
 // Executes CLI commands against the target device (NE).
 func (c *JSONRPCClient) CLI(cmds []string, of formats.EnumOutputFormats) (*Response, error) {
+	return c.CLIContext(context.Background(), cmds, of)
+}
+
+// CLIContext is the same as CLI, but uses the provided context for the request.
+func (c *JSONRPCClient) CLIContext(ctx context.Context, cmds []string, of formats.EnumOutputFormats) (*Response, error) {
 	r, err := NewCLIRequest(cmds, of)
 	if err != nil {
 		return nil, apierr.NewClientError(apierr.CodeClntRPCReqCreation, err)
 	}
-	return c.Do(r)
+	return c.DoContext(ctx, r)
 }
 
 // Helper function to populate default values for the JSONRPCClient.
@@ -430,13 +654,10 @@ func (c *JSONRPCClient) populateDefaults() error {
 	var (
 		defUsername = "admin"
 		defPass     = "NokiaSrl1!" // default password for SRL starting from 22.11. Should we provide "admin" permutation as well to check dynamically?
-		defPort     = 443
 		defTLS      = tls.Config{InsecureSkipVerify: true}
 	)
-	// port
-	if c.target.port == nil {
-		c.target.port = &defPort
-	}
+	// endpoint: scheme, port, URL path
+	c.populateEndpoint()
 
 	// setting the timeout
 	if c.target.timeout == 0 {
@@ -453,11 +674,34 @@ func (c *JSONRPCClient) populateDefaults() error {
 	if c.target.tlsConfig == nil {
 		c.target.tlsConfig = &defTLS // Skipping verification
 	}
+
+	// tracing is disabled by default
+	if c.tracer == nil {
+		c.tracer = noopTracer{}
+	}
+
+	// limit of in-flight asynchronous requests
+	if c.inFlight == nil {
+		c.inFlight = make(chan struct{}, defMaxInFlight)
+	}
+
+	// capabilities registry
+	if c.caps == nil {
+		c.caps = DefaultCapabilities()
+	}
+
+	// authentication provider, falling back to Basic auth with credentials
+	if c.auth == nil {
+		c.auth = basicAuth{username: c.target.username, password: c.target.password}
+	}
+	if _, ok := c.auth.(certAuth); ok && len(c.target.tlsConfig.Certificates) == 0 {
+		return apierr.NewClientError(apierr.CodeClntAuthNoCert, nil)
+	}
 	return nil
 }
 
-// Internal function to verify the target device (NE) version and hostname, which could be used un the future to provide different behavior for different versions.
-func (c *JSONRPCClient) targetVerification() error {
+// Internal function to verify the target device (NE) version and hostname, the version is used to check capabilities of the target before sending requests.
+func (c *JSONRPCClient) targetVerification(ctx context.Context) error {
 	// checking for the system version and hostname
 	hostnameCmd, err := NewCommand(actions.NONE, "/system/name/host-name", CommandValue(""), WithDatastore(datastores.STATE))
 	if err != nil {
@@ -473,17 +717,35 @@ func (c *JSONRPCClient) targetVerification() error {
 		return apierr.NewClientError(apierr.CodeClntRPCReqCreation, err)
 	}
 
-	rpcResp, err := c.Do(r)
+	rpcResp, err := c.doContext(ctx, r)
 	if err != nil {
 		return err
 	}
 
-	var hostAndVer []string
-	if err = json.Unmarshal(rpcResp.Result, &hostAndVer); err != nil {
+	hostname, err := DecodeResult[string](r, rpcResp, 0)
+	if err != nil {
+		return apierr.NewClientError(apierr.CodeClntRespJSONUnmarshalling, err)
+	}
+	sysVer, err := DecodeResult[string](r, rpcResp, 1)
+	if err != nil {
 		return apierr.NewClientError(apierr.CodeClntRespJSONUnmarshalling, err)
 	}
-	c.hostname = hostAndVer[0]
-	c.sysVer = hostAndVer[1]
+	// unknown versions, e.g. development builds, are treated as supporting all capabilities
+	var release *Version
+	if v, err := ParseVersion(sysVer); err == nil {
+		release = &v
+	}
+
+	c.infoMu.Lock()
+	changed := hostname != c.hostname || sysVer != c.sysVer
+	c.hostname, c.sysVer, c.release = hostname, sysVer, release
+	c.infoMu.Unlock()
+	// cached facts are stale if the device was renamed or upgraded
+	if changed {
+		c.factsMu.Lock()
+		c.facts = nil
+		c.factsMu.Unlock()
+	}
 
 	return nil
 }
@@ -524,15 +786,25 @@ func WithOptCredentials(u, p *string) ClientOption {
 
 // ClientOption to specify TLS configuration.
 // Setting the TLS configuration will override the default skipVerify option and will enforce the verification of the server certificate.
-// Assumes minimum TLS version 1.2.
+// Assumes minimum TLS version 1.2. Client certificate and key are loaded whenever provided, regardless of SkipVerify.
 func WithOptTLS(t *TLSAttr) ClientOption {
 	return func(c *JSONRPCClient) error {
 		tlsConfig := tls.Config{}
+		var certFile, keyFile string
+		if t.CertFile != nil {
+			certFile = *t.CertFile
+		}
+		if t.KeyFile != nil {
+			keyFile = *t.KeyFile
+		}
+		if (len(certFile) == 0) != (len(keyFile) == 0) {
+			return apierr.NewClientError(apierr.CodeClntTLSFilesUnspecified, nil)
+		}
 		// Applying skipVerify
 		tlsConfig.InsecureSkipVerify = *t.SkipVerify
 		if !*t.SkipVerify {
 			tlsConfig.ServerName = *c.target.host
-			if len(*t.CAFile) == 0 || (!(len(*t.CertFile) == 0) && len(*t.KeyFile) == 0) || (len(*t.CertFile) == 0 && !(len(*t.KeyFile) == 0)) {
+			if t.CAFile == nil || len(*t.CAFile) == 0 {
 				return apierr.NewClientError(apierr.CodeClntTLSFilesUnspecified, nil)
 			}
 
@@ -553,8 +825,13 @@ func WithOptTLS(t *TLSAttr) ClientOption {
 			}
 			tlsConfig.RootCAs = certCAPool
 
-			// Loading certificate
-			certTLS, err := tls.LoadX509KeyPair(*t.CertFile, *t.KeyFile)
+			// Setting minimum version for TLS1.2 in accordance with specification
+			tlsConfig.MinVersion = tls.VersionTLS12
+		}
+
+		// Loading certificate, also when server verification is skipped, since certificate authentication relies on it
+		if !*t.SkipVerify || len(certFile) != 0 {
+			certTLS, err := tls.LoadX509KeyPair(certFile, keyFile)
 			if err != nil {
 				return apierr.NewClientError(apierr.CodeClntTLSLoadCertPair, err)
 			}
@@ -565,10 +842,6 @@ func WithOptTLS(t *TLSAttr) ClientOption {
 				return apierr.NewClientError(apierr.CodeClntTLSCertParsing, err)
 			}
 			tlsConfig.Certificates = []tls.Certificate{certTLS}
-
-			// Setting minimum version for TLS1.2 in accordance with specification
-			tlsConfig.MinVersion = tls.VersionTLS12
-
 		}
 		c.target.tlsConfig = &tlsConfig
 		return nil
@@ -5,9 +5,11 @@ import (
 	"fmt"
 
 	"github.com/azyablov/srljrpc/actions"
+	"github.com/azyablov/srljrpc/apierr"
 	"github.com/azyablov/srljrpc/ctimeout"
 	"github.com/azyablov/srljrpc/datastores"
 	"github.com/azyablov/srljrpc/formats"
+	"github.com/azyablov/srljrpc/path"
 	"github.com/azyablov/srljrpc/yms"
 )
 
@@ -45,6 +47,32 @@ func NewCommand(action actions.EnumActions, path string, value CommandValue, opt
 	return c, nil
 }
 
+// Constructor for a new Command object with the path built programmatically by path package, see NewCommand.
+// Errors occurred while building the path and syntax errors of the rendered path are reported as apierr.MessageError with code CodeMsgCmdInvalidPath.
+func NewCommandPath(action actions.EnumActions, p *path.Path, value CommandValue, opts ...CommandOption) (*Command, error) {
+	if p == nil {
+		return nil, apierr.NewMessageError(apierr.CodeMsgCmdInvalidPath, fmt.Errorf("nil path is not allowed"))
+	}
+	if err := p.Err(); err != nil {
+		return nil, apierr.NewMessageError(apierr.CodeMsgCmdInvalidPath, err)
+	}
+	return NewCommand(action, p.String(), value, append(opts, WithPathValidation())...)
+}
+
+// CommandOptions to validate syntax of the command path locally, errors are reported as apierr.MessageError with code CodeMsgCmdInvalidPath.
+// Empty path isn't validated, since it's checked by the request.
+func WithPathValidation() CommandOption {
+	return func(c *Command) error {
+		if c.Path == "" {
+			return nil
+		}
+		if err := path.Validate(c.Path); err != nil {
+			return apierr.NewMessageError(apierr.CodeMsgCmdInvalidPath, err)
+		}
+		return nil
+	}
+}
+
 // Provides CommandOptions to disable recursion for the command.
 func WithoutRecursion() CommandOption {
 	return func(c *Command) error {
@@ -75,6 +103,13 @@ func WithDatastore(d datastores.EnumDatastores) CommandOption {
 	}
 }
 
+// CommandOptions to send the command value as JSON value (e.g. container or list) instead of string.
+func WithJSONValue() CommandOption {
+	return func(c *Command) error {
+		return c.withJSONValue()
+	}
+}
+
 // Command is mandatory. List of commands used to execute against the called method. Multiple commands can be executed with a single request.
 // Number of CommandOptions could be used to influence command behavior.
 // Embeds Action and Datastore objects.
@@ -86,6 +121,19 @@ type Command struct {
 	IncludeFieldDefaults *bool           `json:"include-field-defaults,omitempty"`
 	*actions.Action
 	*datastores.Datastore
+	jsonValue bool
+}
+
+// MarshalJSON renders the Command, value set with WithJSONValue is embedded as JSON value.
+func (c Command) MarshalJSON() ([]byte, error) {
+	type command Command
+	if !c.jsonValue {
+		return json.Marshal(command(c))
+	}
+	return json.Marshal(struct {
+		command
+		Value json.RawMessage `json:"value,omitempty"`
+	}{command(c), json.RawMessage(c.Value)})
 }
 
 // Disable recursion for the command. Internal method.
@@ -111,6 +159,15 @@ func (c *Command) withPathKeywords(jrm json.RawMessage) error {
 	return nil
 }
 
+// Mark command value as JSON value. Internal method.
+func (c *Command) withJSONValue() error {
+	if !json.Valid([]byte(c.Value)) {
+		return fmt.Errorf("command value is not valid JSON")
+	}
+	c.jsonValue = true
+	return nil
+}
+
 // Set datastore for the command. Internal method.
 func (c *Command) withDatastore(ds datastores.EnumDatastores) error {
 	c.Datastore = &datastores.Datastore{}
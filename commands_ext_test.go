@@ -71,3 +71,21 @@ func TestNewCommand(t *testing.T) {
 	}
 
 }
+
+func TestCommandWithJSONValue(t *testing.T) {
+	cmd, err := srljrpc.NewCommand(actions.REPLACE, "/interface[name=mgmt0]", srljrpc.CommandValue(`{"admin-state":"enable"}`), srljrpc.WithJSONValue())
+	if err != nil {
+		t.Fatalf("creation error: %s", err)
+	}
+	b, err := json.Marshal(cmd)
+	if err != nil {
+		t.Fatalf("marshalling error: %s", err)
+	}
+	exp := `{"path":"/interface[name=mgmt0]","action":"replace","value":{"admin-state":"enable"}}`
+	if diff := cmp.Diff(exp, string(b)); diff != "" {
+		t.Errorf("unexpected JSON (-want +got):\n%s", diff)
+	}
+	if _, err := srljrpc.NewCommand(actions.REPLACE, "/interface[name=mgmt0]", srljrpc.CommandValue(`{`), srljrpc.WithJSONValue()); err == nil {
+		t.Errorf("expected error for invalid JSON value")
+	}
+}
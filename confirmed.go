@@ -0,0 +1,149 @@
+package srljrpc
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/azyablov/srljrpc/apierr"
+	"github.com/azyablov/srljrpc/datastores"
+	"github.com/azyablov/srljrpc/formats"
+	"github.com/azyablov/srljrpc/yms"
+)
+
+// Paths of TOOLS datastore to accept or reject commit confirmed in progress.
+const (
+	confirmedAcceptPath = "/system/configuration/confirmed-accept"
+	confirmedRejectPath = "/system/configuration/confirmed-reject"
+)
+
+// CommitStatus type to represent the status of the confirmed commit.
+type CommitStatus int
+
+const (
+	CommitPending  CommitStatus = iota // waiting for accept or reject
+	CommitAccepted                     // accepted by Accept()
+	CommitRejected                     // rejected by Reject() and rolled back
+	CommitExpired                      // confirm timeout expired, so changes are rolled back by NE
+)
+
+// ConfirmedCommit type to represent commit confirmed in progress returned by BulkSetConfirmed.
+// Methods are safe for concurrent use, the client could be used for other requests meanwhile (e.g. health checks).
+type ConfirmedCommit struct {
+	Request  *Request
+	Response *Response
+
+	c        *JSONRPCClient
+	deadline time.Time
+	timer    *time.Timer
+	done     chan struct{}
+	op       sync.Mutex // serializes accept and reject
+	mu       sync.Mutex // protects status
+	status   CommitStatus
+}
+
+// Bulk CRUD method of JSONRPCClient with mandatory confirm timeout, which doesn't wait for the confirmation.
+// Executes a SET method with REPLACE/UPDATE/DELETE action request against CANDIDATE datastore and returns ConfirmedCommit handle
+// to accept or reject the changes before ct seconds expire.
+func (c *JSONRPCClient) BulkSetConfirmed(delete []PV, replace []PV, update []PV, ym yms.EnumYmType, ct int) (*ConfirmedCommit, error) {
+	return c.BulkSetConfirmedContext(context.Background(), delete, replace, update, ym, ct)
+}
+
+// BulkSetConfirmedContext is the same as BulkSetConfirmed, but uses the provided context for the request.
+func (c *JSONRPCClient) BulkSetConfirmedContext(ctx context.Context, delete []PV, replace []PV, update []PV, ym yms.EnumYmType, ct int) (*ConfirmedCommit, error) {
+	if ct <= 0 {
+		return nil, apierr.NewClientError(apierr.CodeClntCommitNoTimeout, nil)
+	}
+	req, err := NewSetRequest(delete, replace, update, ym, formats.JSON, datastores.CANDIDATE, ct)
+	if err != nil {
+		return nil, apierr.NewClientError(apierr.CodeClntRPCReqCreation, err)
+	}
+	start := time.Now()
+	resp, err := c.DoContext(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	cc := &ConfirmedCommit{
+		Request:  req,
+		Response: resp,
+		c:        c,
+		deadline: start.Add(time.Duration(ct) * time.Second),
+		done:     make(chan struct{}),
+	}
+	// timer is set under the lock, since it could fire before assignment
+	cc.mu.Lock()
+	cc.timer = time.AfterFunc(time.Until(cc.deadline), func() {
+		cc.finish(CommitExpired)
+	})
+	cc.mu.Unlock()
+	return cc, nil
+}
+
+// Accept confirms the changes via TOOLS datastore /system/configuration/confirmed-accept.
+func (cc *ConfirmedCommit) Accept() error {
+	return cc.AcceptContext(context.Background())
+}
+
+// AcceptContext is the same as Accept, but uses the provided context for the request.
+func (cc *ConfirmedCommit) AcceptContext(ctx context.Context) error {
+	return cc.close(ctx, confirmedAcceptPath, CommitAccepted)
+}
+
+// Reject rolls back the changes immediately via TOOLS datastore /system/configuration/confirmed-reject.
+func (cc *ConfirmedCommit) Reject() error {
+	return cc.RejectContext(context.Background())
+}
+
+// RejectContext is the same as Reject, but uses the provided context for the request.
+func (cc *ConfirmedCommit) RejectContext(ctx context.Context) error {
+	return cc.close(ctx, confirmedRejectPath, CommitRejected)
+}
+
+// Remaining returns the time left till confirm timeout expiration, zero if the commit isn't pending anymore.
+func (cc *ConfirmedCommit) Remaining() time.Duration {
+	if cc.Status() != CommitPending {
+		return 0
+	}
+	if d := time.Until(cc.deadline); d > 0 {
+		return d
+	}
+	return 0
+}
+
+// Done returns a channel that's closed when the commit is accepted, rejected or confirm timeout expired.
+func (cc *ConfirmedCommit) Done() <-chan struct{} {
+	return cc.done
+}
+
+// Status returns the current status of the commit.
+func (cc *ConfirmedCommit) Status() CommitStatus {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	return cc.status
+}
+
+// Helper method to accept or reject the commit.
+func (cc *ConfirmedCommit) close(ctx context.Context, p string, s CommitStatus) error {
+	cc.op.Lock()
+	defer cc.op.Unlock()
+	if cc.Status() != CommitPending {
+		return apierr.NewClientError(apierr.CodeClntCommitDone, nil)
+	}
+	if _, err := cc.c.ToolsContext(ctx, PV{Path: p, Value: CommandValue("")}); err != nil {
+		return err
+	}
+	cc.finish(s)
+	return nil
+}
+
+// Helper method to set the final status of the commit, the first call wins.
+func (cc *ConfirmedCommit) finish(s CommitStatus) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	if cc.status != CommitPending {
+		return
+	}
+	cc.status = s
+	cc.timer.Stop()
+	close(cc.done)
+}
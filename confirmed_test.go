@@ -0,0 +1,80 @@
+//go:build unit
+
+package srljrpc_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/azyablov/srljrpc"
+	"github.com/azyablov/srljrpc/apierr"
+	"github.com/azyablov/srljrpc/yms"
+)
+
+func TestConfirmedCommit(t *testing.T) {
+	s, c := helperSimClient(t)
+	descPath := "/interface[name=system0]/description"
+	pv := []srljrpc.PV{{Path: descPath, Value: srljrpc.CommandValue("CONFIRMED")}}
+
+	// Accepted, while other requests are not blocked
+	cc, err := c.BulkSetConfirmed(nil, nil, pv, yms.SRL, 60)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if r := cc.Remaining(); r <= 0 || r > 60*time.Second || cc.Status() != srljrpc.CommitPending {
+		t.Errorf("unexpected pending commit: remaining %v, status %v", r, cc.Status())
+	}
+	if _, err := c.Get(descPath); err != nil {
+		t.Errorf("unexpected error while commit is pending: %v", err)
+	}
+	if err := cc.Accept(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	select {
+	case <-cc.Done():
+	default:
+		t.Errorf("expected Done channel closed after accept")
+	}
+	if cc.Status() != srljrpc.CommitAccepted || cc.Remaining() != 0 {
+		t.Errorf("unexpected accepted commit: remaining %v, status %v", cc.Remaining(), cc.Status())
+	}
+	if err := cc.Reject(); !errors.Is(err, apierr.ErrClntCommitDone) {
+		t.Errorf("expected error %v, got %v", apierr.ErrClntCommitDone, err)
+	}
+
+	// Rejected and rolled back immediately
+	pv[0].Value = "REJECTED"
+	cc, err = c.BulkSetConfirmed(nil, nil, pv, yms.SRL, 60)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := cc.Reject(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v, _ := s.Get("running", descPath); v != "CONFIRMED" || cc.Status() != srljrpc.CommitRejected {
+		t.Errorf("expected CONFIRMED after reject, got %v, status %v", v, cc.Status())
+	}
+
+	// Expired and rolled back by NE
+	pv[0].Value = "EXPIRED"
+	cc, err = c.BulkSetConfirmed(nil, nil, pv, yms.SRL, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	select {
+	case <-cc.Done():
+	case <-time.After(3 * time.Second):
+		t.Fatalf("confirmed commit is not expired")
+	}
+	if cc.Status() != srljrpc.CommitExpired {
+		t.Errorf("expected expired commit, got status %v", cc.Status())
+	}
+	if err := cc.Accept(); !errors.Is(err, apierr.ErrClntCommitDone) {
+		t.Errorf("expected error %v, got %v", apierr.ErrClntCommitDone, err)
+	}
+
+	if _, err := c.BulkSetConfirmed(nil, nil, pv, yms.SRL, 0); !errors.Is(err, apierr.ErrClntCommitNoTimeout) {
+		t.Errorf("expected error %v, got %v", apierr.ErrClntCommitNoTimeout, err)
+	}
+}
@@ -0,0 +1,77 @@
+//go:build unit
+
+package srljrpc_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/azyablov/srljrpc"
+	"github.com/azyablov/srljrpc/apierr"
+)
+
+// Helper function to start TLS server answering target verification and blocking on any other request until it is canceled.
+func helperBlockingServer(t *testing.T) (*httptest.Server, string, int) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req srljrpc.Request
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if len(req.Params.Commands) == 2 && req.Params.Commands[0].Path == "/system/name/host-name" {
+			json.NewEncoder(w).Encode(srljrpc.Response{JSONRpcVersion: "2.0", ID: req.ID, Result: json.RawMessage(`["leaf1","v23.3.1-343-gab924f2e64"]`)})
+			return
+		}
+		<-r.Context().Done()
+	}))
+	u, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("can't parse test server URL: %v", err)
+	}
+	port, err := strconv.Atoi(u.Port())
+	if err != nil {
+		t.Fatalf("can't parse test server port: %v", err)
+	}
+	return ts, u.Hostname(), port
+}
+
+func TestDoContext(t *testing.T) {
+	ts, host, port := helperBlockingServer(t)
+	defer ts.Close()
+
+	c, err := srljrpc.NewJSONRPCClientContext(context.Background(), &host, srljrpc.WithOptPort(&port))
+	if err != nil {
+		t.Fatalf("can't create client: %v", err)
+	}
+	if c.GetHostname() != "leaf1" {
+		t.Fatalf("expected hostname leaf1, got %s", c.GetHostname())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err = c.GetContext(ctx, "/system/name")
+	if !errors.Is(err, apierr.ErrClntCtxDone) {
+		t.Errorf("expected error %v, got %v", apierr.ErrClntCtxDone, err)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected error to wrap %v, got %v", context.DeadlineExceeded, err)
+	}
+
+	// Canceled context must prevent client creation.
+	cctx, ccancel := context.WithCancel(context.Background())
+	ccancel()
+	_, err = srljrpc.NewJSONRPCClientContext(cctx, &host, srljrpc.WithOptPort(&port))
+	if !errors.Is(err, apierr.ErrClntTargetVerification) {
+		t.Errorf("expected error %v, got %v", apierr.ErrClntTargetVerification, err)
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected error to wrap %v, got %v", context.Canceled, err)
+	}
+}
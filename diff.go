@@ -0,0 +1,414 @@
+package srljrpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/azyablov/srljrpc/actions"
+	"github.com/azyablov/srljrpc/apierr"
+	"github.com/azyablov/srljrpc/datastores"
+	"github.com/azyablov/srljrpc/formats"
+	"github.com/azyablov/srljrpc/methods"
+	"github.com/azyablov/srljrpc/path"
+	"github.com/azyablov/srljrpc/yms"
+)
+
+// Key leaves probed in order of appearance to identify YANG list keys, if keys aren't specified by WithDiffListKeys,
+// key leaves set by WithDiffKeyNames are probed first.
+var defaultListKeys = []string{"name", "index", "id", "sequence-id", "peer-address", "address", "ip-prefix", "prefix", "vlan-id"}
+
+// ConfigDiff type to represent structural difference between two configuration trees as SET operations,
+// which are applied in order: Delete, Replace, Update. Module prefixes are stripped from the path elements
+// and leaves are compared as rendered command values.
+// Update values are leaf values, while Replace values are JSON encoded empty leaves, leaf-lists, containers and lists w/o identified keys.
+// Nodes changing kind, e.g. container turned into leaf, are deleted before being created again.
+// Replace values must be sent as JSON values, which is done by SetRequest(), while other APIs taking PVs send values as strings.
+type ConfigDiff struct {
+	Delete  []PV
+	Replace []PV
+	Update  []PV
+}
+
+// DiffOption is a function type that applies options to DiffConfig.
+type DiffOption func(*diffOpts)
+
+type diffOpts struct {
+	root     string
+	keys     map[string][]string
+	keyNames []string
+}
+
+// DiffOption to set the path both trees are retrieved from, e.g. path of the Get request. Default is root.
+func WithDiffRoot(p string) DiffOption {
+	return func(o *diffOpts) {
+		o.root = p
+	}
+}
+
+// DiffOption to set key leaves of the YANG list, list name is w/o module prefix.
+func WithDiffListKeys(list string, keys ...string) DiffOption {
+	return func(o *diffOpts) {
+		o.keys[list] = keys
+	}
+}
+
+// DiffOption to add key leaves probed to identify keys of any YANG list, e.g. keys of the lists not covered by defaults.
+func WithDiffKeyNames(names ...string) DiffOption {
+	return func(o *diffOpts) {
+		o.keyNames = append(o.keyNames, names...)
+	}
+}
+
+// DiffConfig compares configuration trees locally and returns operations to turn from tree into to tree.
+// Trees are JSON objects as returned in results of Get, State or Backup. Empty tree is allowed.
+func DiffConfig(from, to json.RawMessage, opts ...DiffOption) (*ConfigDiff, error) {
+	o := diffOpts{root: "/", keys: map[string][]string{}}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	root, err := path.Parse(o.root)
+	if err != nil {
+		return nil, apierr.NewMessageError(apierr.CodeMsgCmdInvalidPath, err)
+	}
+	a, err := decodeTree(from)
+	if err != nil {
+		return nil, err
+	}
+	b, err := decodeTree(to)
+	if err != nil {
+		return nil, err
+	}
+	d := &ConfigDiff{}
+	if err := d.container(&o, root, a, b); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// Len returns number of operations in the diff.
+func (d *ConfigDiff) Len() int {
+	return len(d.Delete) + len(d.Replace) + len(d.Update)
+}
+
+// SetRequest provides a new Request with the SET method against CANDIDATE datastore applying the diff, Replace values are sent as JSON values.
+// ct is the timeout in seconds for the confirm operation, set to 0 to disable.
+func (d *ConfigDiff) SetRequest(ym yms.EnumYmType, ct int) (*Request, error) {
+	var cmds []*Command
+	for _, pv := range d.Delete {
+		cmd, err := NewCommand(actions.DELETE, pv.Path, CommandValue(""))
+		if err != nil {
+			return nil, apierr.NewMessageError(apierr.CodeMsgCmdCreation, err)
+		}
+		cmds = append(cmds, cmd)
+	}
+	for _, pv := range d.Replace {
+		cmd, err := NewCommand(actions.REPLACE, pv.Path, pv.Value, WithJSONValue())
+		if err != nil {
+			return nil, apierr.NewMessageError(apierr.CodeMsgCmdCreation, err)
+		}
+		cmds = append(cmds, cmd)
+	}
+	for _, pv := range d.Update {
+		cmd, err := NewCommand(actions.UPDATE, pv.Path, pv.Value)
+		if err != nil {
+			return nil, apierr.NewMessageError(apierr.CodeMsgCmdCreation, err)
+		}
+		cmds = append(cmds, cmd)
+	}
+	if ct == 0 {
+		return NewRequest(methods.SET, cmds, WithRequestDatastore(datastores.CANDIDATE), WithYmType(ym), WithOutputFormat(formats.JSON))
+	}
+	return NewRequest(methods.SET, cmds, WithRequestDatastore(datastores.CANDIDATE), WithYmType(ym), WithOutputFormat(formats.JSON), WithConfirmTimeout(ct))
+}
+
+// Helper function to decode configuration tree, numbers are kept as is.
+func decodeTree(raw json.RawMessage) (map[string]interface{}, error) {
+	if len(bytes.TrimSpace(raw)) == 0 {
+		return map[string]interface{}{}, nil
+	}
+	var tree map[string]interface{}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	if err := dec.Decode(&tree); err != nil {
+		return nil, apierr.NewClientError(apierr.CodeClntDiffTree, err)
+	}
+	if tree == nil {
+		tree = map[string]interface{}{}
+	}
+	return tree, nil
+}
+
+// Helper function to extend the path with element stripping module prefix.
+func diffElem(p *path.Path, name string) *path.Path {
+	return p.Copy().Elem(name).Prefix("")
+}
+
+// Helper function to sort the union of container children names.
+func unionKeys(a, b map[string]interface{}) []string {
+	var keys []string
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Helper method comparing children of the containers.
+func (d *ConfigDiff) container(o *diffOpts, p *path.Path, a, b map[string]interface{}) error {
+	for _, k := range unionKeys(a, b) {
+		av, aok := a[k]
+		bv, bok := b[k]
+		cp := diffElem(p, k)
+		if err := cp.Err(); err != nil {
+			return apierr.NewMessageError(apierr.CodeMsgCmdInvalidPath, err)
+		}
+		switch {
+		case !bok:
+			d.Delete = append(d.Delete, PV{Path: cp.String()})
+		case !aok:
+			if err := d.create(o, cp, k, bv); err != nil {
+				return err
+			}
+		default:
+			if err := d.node(o, cp, k, av, bv); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Helper method comparing nodes present in both trees.
+func (d *ConfigDiff) node(o *diffOpts, p *path.Path, name string, a, b interface{}) error {
+	am, aIsMap := a.(map[string]interface{})
+	bm, bIsMap := b.(map[string]interface{})
+	if aIsMap && bIsMap {
+		return d.container(o, p, am, bm)
+	}
+	al, aIsList := a.([]interface{})
+	bl, bIsList := b.([]interface{})
+	if aIsList && bIsList && isEntryList(al) && isEntryList(bl) {
+		if keys := o.listKeys(name, al, bl); keys != nil {
+			return d.list(o, p, keys, al, bl)
+		}
+	}
+	if equalNodes(a, b) {
+		return nil
+	}
+	if nodeKind(a) != nodeKind(b) {
+		// e.g. container turned into leaf, the old subtree must be deleted
+		d.Delete = append(d.Delete, PV{Path: p.String()})
+	}
+	return d.create(o, p, name, b)
+}
+
+// Helper function returning kind of the node: 'c' for containers, 'l' for lists and leaf-lists, 's' for leaves.
+func nodeKind(v interface{}) byte {
+	switch v.(type) {
+	case map[string]interface{}:
+		return 'c'
+	case []interface{}:
+		return 'l'
+	default:
+		return 's'
+	}
+}
+
+// Helper method adding operations to create the node. Containers and list entries are expanded to leaves.
+func (d *ConfigDiff) create(o *diffOpts, p *path.Path, name string, v interface{}) error {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		if len(t) == 0 {
+			return d.replace(p, t)
+		}
+		return d.container(o, p, map[string]interface{}{}, t)
+	case []interface{}:
+		if isEntryList(t) {
+			if keys := o.listKeys(name, t, nil); keys != nil {
+				return d.list(o, p, keys, nil, t)
+			}
+		}
+		return d.replace(p, t)
+	default:
+		if scalarString(t) == "" {
+			// empty value can't be sent as command value, so it's replaced with JSON encoded one
+			return d.replace(p, t)
+		}
+		d.Update = append(d.Update, PV{Path: p.String(), Value: CommandValue(scalarString(t))})
+		return nil
+	}
+}
+
+// Helper method adding Replace operation with JSON encoded value.
+func (d *ConfigDiff) replace(p *path.Path, v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return apierr.NewClientError(apierr.CodeClntDiffTree, err)
+	}
+	d.Replace = append(d.Replace, PV{Path: p.String(), Value: CommandValue(b)})
+	return nil
+}
+
+// Helper method comparing list entries matched by keys.
+func (d *ConfigDiff) list(o *diffOpts, p *path.Path, keys []string, a, b []interface{}) error {
+	index := func(l []interface{}) (map[string]map[string]interface{}, []string) {
+		m := map[string]map[string]interface{}{}
+		var order []string
+		for _, le := range l {
+			e := le.(map[string]interface{})
+			id := entryID(e, keys)
+			m[id] = e
+			order = append(order, id)
+		}
+		return m, order
+	}
+	am, aOrder := index(a)
+	bm, bOrder := index(b)
+	for _, id := range aOrder {
+		if _, ok := bm[id]; !ok {
+			d.Delete = append(d.Delete, PV{Path: entryPath(p, keys, am[id]).String()})
+		}
+	}
+	for _, id := range bOrder {
+		be := withoutKeys(bm[id], keys)
+		ep := entryPath(p, keys, bm[id])
+		ae, ok := am[id]
+		if !ok && len(be) == 0 {
+			// list entry w/o leaves except keys
+			if err := d.replace(ep, map[string]interface{}{}); err != nil {
+				return err
+			}
+			continue
+		}
+		if !ok {
+			ae = map[string]interface{}{}
+		}
+		if err := d.container(o, ep, withoutKeys(ae, keys), be); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Helper method to identify keys of the list: keys set by WithDiffListKeys or the first of key names and default keys,
+// which is present in every entry with unique scalar value. Returns nil if keys aren't identified.
+func (o *diffOpts) listKeys(name string, a, b []interface{}) []string {
+	if i := strings.LastIndexByte(name, ':'); i >= 0 {
+		name = name[i+1:]
+	}
+	if keys, ok := o.keys[name]; ok {
+		if validKeys(keys, a) && validKeys(keys, b) {
+			return keys
+		}
+		return nil
+	}
+	for _, k := range append(o.keyNames[:len(o.keyNames):len(o.keyNames)], defaultListKeys...) {
+		keys := []string{k}
+		if validKeys(keys, a) && validKeys(keys, b) {
+			return keys
+		}
+	}
+	return nil
+}
+
+// Helper function to check if every list entry has scalar values of the keys, which are unique.
+func validKeys(keys []string, l []interface{}) bool {
+	if len(keys) == 0 {
+		return false
+	}
+	seen := map[string]bool{}
+	for _, le := range l {
+		e := le.(map[string]interface{})
+		for _, k := range keys {
+			switch e[k].(type) {
+			case string, json.Number, bool:
+			default:
+				return false
+			}
+		}
+		id := entryID(e, keys)
+		if seen[id] {
+			return false
+		}
+		seen[id] = true
+	}
+	return true
+}
+
+// Helper function to check if the JSON array is a list of entries, and not a leaf-list.
+func isEntryList(l []interface{}) bool {
+	if len(l) == 0 {
+		return false
+	}
+	for _, le := range l {
+		if _, ok := le.(map[string]interface{}); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// Helper function building unique identifier of the list entry from key values.
+func entryID(e map[string]interface{}, keys []string) string {
+	vals := make([]string, 0, len(keys))
+	for _, k := range keys {
+		vals = append(vals, scalarString(e[k]))
+	}
+	b, _ := json.Marshal(vals)
+	return string(b)
+}
+
+// Helper function building path of the list entry.
+func entryPath(p *path.Path, keys []string, e map[string]interface{}) *path.Path {
+	ep := p.Copy()
+	for _, k := range keys {
+		ep.Key(k, scalarString(e[k]))
+	}
+	return ep
+}
+
+// Helper function returning list entry w/o key leaves, which are a part of the path.
+func withoutKeys(e map[string]interface{}, keys []string) map[string]interface{} {
+	out := make(map[string]interface{}, len(e))
+	for k, v := range e {
+		out[k] = v
+	}
+	for _, k := range keys {
+		delete(out, k)
+	}
+	return out
+}
+
+// Helper function comparing nodes, leaf values are compared as rendered command values, e.g. 9000 equals to "9000".
+func equalNodes(a, b interface{}) bool {
+	switch a.(type) {
+	case map[string]interface{}, []interface{}:
+		return reflect.DeepEqual(a, b)
+	}
+	switch b.(type) {
+	case map[string]interface{}, []interface{}:
+		return false
+	}
+	return scalarString(a) == scalarString(b)
+}
+
+// Helper function rendering scalar JSON value as command value.
+func scalarString(v interface{}) string {
+	switch t := v.(type) {
+	case string:
+		return t
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(t)
+	}
+}
@@ -0,0 +1,210 @@
+//go:build unit
+
+package srljrpc_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/azyablov/srljrpc"
+	"github.com/azyablov/srljrpc/apierr"
+	"github.com/azyablov/srljrpc/yms"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestDiffConfig(t *testing.T) {
+	var testData = []struct {
+		testName string
+		from     string
+		to       string
+		opts     []srljrpc.DiffOption
+		exp      *srljrpc.ConfigDiff
+		expErr   error
+	}{
+		{testName: "Identical trees",
+			from: `{"system":{"name":{"host-name":"leaf1"}}}`, to: `{"system":{"name":{"host-name":"leaf1"}}}`,
+			exp: &srljrpc.ConfigDiff{}},
+		{testName: "Leaf update and delete",
+			from: `{"system":{"name":{"host-name":"leaf1","domain-name":"lab"}}}`, to: `{"system":{"name":{"host-name":"leaf2"}}}`,
+			exp: &srljrpc.ConfigDiff{
+				Delete: []srljrpc.PV{{Path: "/system/name/domain-name"}},
+				Update: []srljrpc.PV{{Path: "/system/name/host-name", Value: "leaf2"}}}},
+		{testName: "List entries matched by keys",
+			from: `{"srl_nokia-interfaces:interface":[{"name":"ethernet-1/1","mtu":9000},{"name":"ethernet-1/2","admin-state":"enable"}]}`,
+			to:   `{"srl_nokia-interfaces:interface":[{"name":"ethernet-1/1","mtu":1500},{"name":"ethernet-1/3","subinterface":[{"index":0,"description":"MAC-VRF 1"}]}]}`,
+			exp: &srljrpc.ConfigDiff{
+				Delete: []srljrpc.PV{{Path: "/interface[name=ethernet-1/2]"}},
+				Update: []srljrpc.PV{
+					{Path: "/interface[name=ethernet-1/1]/mtu", Value: "1500"},
+					{Path: "/interface[name=ethernet-1/3]/subinterface[index=0]/description", Value: "MAC-VRF 1"}}}},
+		{testName: "Leaf-list and empty container replaced",
+			from: `{"system":{"dns":{"server-list":["1.1.1.1"]}}}`, to: `{"system":{"dns":{"server-list":["1.1.1.1","8.8.8.8"]},"lldp":{}}}`,
+			exp: &srljrpc.ConfigDiff{
+				Replace: []srljrpc.PV{
+					{Path: "/system/dns/server-list", Value: `["1.1.1.1","8.8.8.8"]`},
+					{Path: "/system/lldp", Value: `{}`}}}},
+		{testName: "Empty leaf replaced",
+			from: `{"system":{"name":{"host-name":"leaf1","domain-name":"lab"}}}`, to: `{"system":{"name":{"host-name":"leaf1","domain-name":""}}}`,
+			exp: &srljrpc.ConfigDiff{
+				Replace: []srljrpc.PV{{Path: "/system/name/domain-name", Value: `""`}}}},
+		{testName: "Container turned into leaf",
+			from: `{"system":{"banner":{"login-banner":"hello"}}}`, to: `{"system":{"banner":"hello"}}`,
+			exp: &srljrpc.ConfigDiff{
+				Delete: []srljrpc.PV{{Path: "/system/banner"}},
+				Update: []srljrpc.PV{{Path: "/system/banner", Value: "hello"}}}},
+		{testName: "List turned into empty leaf",
+			from: `{"acl":{"entry":[{"seq":1}]}}`, to: `{"acl":{"entry":""}}`,
+			exp: &srljrpc.ConfigDiff{
+				Delete:  []srljrpc.PV{{Path: "/acl/entry"}},
+				Replace: []srljrpc.PV{{Path: "/acl/entry", Value: `""`}}}},
+		{testName: "List w/o identified keys replaced",
+			from: `{"acl":{"entry":[{"seq":1}]}}`, to: `{"acl":{"entry":[{"seq":2}]}}`,
+			exp: &srljrpc.ConfigDiff{
+				Replace: []srljrpc.PV{{Path: "/acl/entry", Value: `[{"seq":2}]`}}}},
+		{testName: "List keys specified",
+			from: `{"acl":{"entry":[{"seq":1,"type":"ipv4","action":"drop"}]}}`, to: `{"acl":{"entry":[{"seq":1,"type":"ipv4","action":"accept"}]}}`,
+			opts: []srljrpc.DiffOption{srljrpc.WithDiffListKeys("entry", "seq", "type")},
+			exp: &srljrpc.ConfigDiff{
+				Update: []srljrpc.PV{{Path: "/acl/entry[seq=1][type=ipv4]/action", Value: "accept"}}}},
+		{testName: "Key names specified",
+			from: `{"filter":{"rule":[{"rule-id":1,"action":"drop"},{"rule-id":2,"action":"drop"}]}}`, to: `{"filter":{"rule":[{"rule-id":1,"action":"accept"},{"rule-id":2,"action":"drop"}]}}`,
+			opts: []srljrpc.DiffOption{srljrpc.WithDiffKeyNames("rule-id")},
+			exp: &srljrpc.ConfigDiff{
+				Update: []srljrpc.PV{{Path: "/filter/rule[rule-id=1]/action", Value: "accept"}}}},
+		{testName: "Trees under root path",
+			from: `{"description":"old"}`, to: `{"description":"MAC-VRF 1"}`,
+			opts: []srljrpc.DiffOption{srljrpc.WithDiffRoot("/network-instance[name=MAC-VRF 1]")},
+			exp: &srljrpc.ConfigDiff{
+				Update: []srljrpc.PV{{Path: `/network-instance[name="MAC-VRF 1"]/description`, Value: "MAC-VRF 1"}}}},
+		{testName: "Empty from tree",
+			from: ``, to: `{"system":{"name":{"host-name":"leaf1"}}}`,
+			exp: &srljrpc.ConfigDiff{
+				Update: []srljrpc.PV{{Path: "/system/name/host-name", Value: "leaf1"}}}},
+		{testName: "Invalid tree",
+			from: `[1]`, to: `{}`, expErr: apierr.ErrClntDiffTree},
+		{testName: "Invalid root path",
+			from: `{}`, to: `{}`, opts: []srljrpc.DiffOption{srljrpc.WithDiffRoot("/interface[name=a")}, expErr: apierr.ErrMsgCmdInvalidPath},
+	}
+	for _, td := range testData {
+		t.Run(td.testName, func(t *testing.T) {
+			d, err := srljrpc.DiffConfig(json.RawMessage(td.from), json.RawMessage(td.to), td.opts...)
+			if td.expErr != nil {
+				if !errors.Is(err, td.expErr) {
+					t.Errorf("expected error %v, got %v", td.expErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if out := cmp.Diff(td.exp, d, cmpopts.EquateEmpty()); out != "" {
+				t.Errorf("unexpected diff (-want +got):\n%s", out)
+			}
+		})
+	}
+}
+
+func TestDiffConfigApply(t *testing.T) {
+	s, c := helperSimClient(t)
+	getRoot := func() json.RawMessage {
+		resp, err := c.Get("/")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		raw, err := srljrpc.DecodeResult[json.RawMessage](nil, resp, 0)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return raw
+	}
+
+	initial := getRoot()
+	if _, err := c.Update(0, srljrpc.PV{Path: "/interface[name=system0]/description", Value: "CHANGED"},
+		srljrpc.PV{Path: "/interface[name=ethernet-1/9]/description", Value: "NEW"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	d, err := srljrpc.DiffConfig(getRoot(), initial)
+	if err != nil || d.Len() == 0 {
+		t.Fatalf("unexpected diff: %+v, %v", d, err)
+	}
+	req, err := d.SetRequest(yms.SRL, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := c.Do(req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// replace values are sent as JSON, including empty leaves
+	if err := s.Set("running", "/system/dns", map[string]interface{}{"server-list": []interface{}{"1.1.1.1"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Set("running", "/interface[name=system0]/description", ""); err != nil {
+		t.Fatal(err)
+	}
+	d, err = srljrpc.DiffConfig(initial, getRoot())
+	if err != nil || len(d.Replace) != 2 {
+		t.Fatalf("expected 2 replaces in diff: %+v, %v", d, err)
+	}
+	if _, err := c.Delete(0, "/system/dns", "/interface[name=system0]/description"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	req, err = d.SetRequest(yms.SRL, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := c.Do(req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v, err := s.Get("running", "/system/dns"); err != nil || !cmp.Equal(v, map[string]interface{}{"server-list": []interface{}{"1.1.1.1"}}) {
+		t.Errorf("expected server-list set as JSON value, got %#v, %v", v, err)
+	}
+	if v, err := s.Get("running", "/interface[name=system0]/description"); err != nil || v != "" {
+		t.Errorf("expected empty description, got %#v, %v", v, err)
+	}
+	if _, err := c.Delete(0, "/system/dns", "/interface[name=system0]/description"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d, err := srljrpc.DiffConfig(getRoot(), initial); err != nil || d.Len() != 0 {
+		t.Errorf("expected no difference after applying diff, got %+v, %v", d, err)
+	}
+	if v, err := s.Get("running", "/interface[name=ethernet-1/9]"); err != nil || len(v.(map[string]interface{})) != 0 {
+		t.Errorf("expected interface ethernet-1/9 deleted, got %v, %v", v, err)
+	}
+}
+
+func TestDiffSetRequest(t *testing.T) {
+	d := &srljrpc.ConfigDiff{
+		Replace: []srljrpc.PV{{Path: "/system/dns/server-list", Value: `["1.1.1.1"]`}},
+		Update:  []srljrpc.PV{{Path: "/system/name/host-name", Value: "[1]"}},
+	}
+	req, err := d.SetRequest(yms.SRL, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b, err := req.Marshal()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got struct {
+		Params struct {
+			Commands []struct {
+				Value json.RawMessage `json:"value"`
+			} `json:"commands"`
+		} `json:"params"`
+	}
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var vals []string
+	for _, cmd := range got.Params.Commands {
+		vals = append(vals, string(cmd.Value))
+	}
+	// replace values are sent as JSON, update values are sent as strings
+	if out := cmp.Diff([]string{`["1.1.1.1"]`, `"[1]"`}, vals); out != "" {
+		t.Errorf("unexpected values (-want +got):\n%s", out)
+	}
+}
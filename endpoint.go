@@ -0,0 +1,108 @@
+package srljrpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/azyablov/srljrpc/apierr"
+)
+
+// Default values of the endpoint.
+const (
+	DefaultScheme  = "https"
+	DefaultURLPath = "/jsonrpc"
+)
+
+// Endpoint type to represent JSON RPC server endpoint of the target.
+type Endpoint struct {
+	Scheme string // http or https
+	Host   string // host name, IPv4 or IPv6 address w/o brackets
+	Port   int
+	Path   string // URL path of JSON RPC server
+	Socket string // unix socket path, Host and Port are used only to build URL if set
+}
+
+// URL returns URL of the endpoint, IPv6 addresses are enclosed in brackets.
+func (e Endpoint) URL() string {
+	u := url.URL{Scheme: e.Scheme, Host: net.JoinHostPort(e.Host, strconv.Itoa(e.Port)), Path: e.Path}
+	return u.String()
+}
+
+// Endpoint returns JSON RPC server endpoint of the target.
+func (c *JSONRPCClient) Endpoint() Endpoint {
+	return Endpoint{
+		Scheme: c.target.scheme,
+		Host:   *c.target.host,
+		Port:   *c.target.port,
+		Path:   c.target.path,
+		Socket: c.target.socket,
+	}
+}
+
+// ClientOption to set URL scheme of the endpoint: https (default) or http for plain HTTP JSON RPC server.
+func WithOptScheme(scheme string) ClientOption {
+	return func(c *JSONRPCClient) error {
+		switch scheme {
+		case "http", "https":
+		default:
+			return apierr.NewClientError(apierr.CodeClntEndpoint, fmt.Errorf("unsupported scheme %q, while should be http / https", scheme))
+		}
+		c.target.scheme = scheme
+		return nil
+	}
+}
+
+// ClientOption to set URL path of the endpoint, /jsonrpc by default.
+func WithOptURLPath(p string) ClientOption {
+	return func(c *JSONRPCClient) error {
+		if !strings.HasPrefix(p, "/") {
+			return apierr.NewClientError(apierr.CodeClntEndpoint, fmt.Errorf("URL path %q must start with /", p))
+		}
+		c.target.path = p
+		return nil
+	}
+}
+
+// ClientOption to connect to JSON RPC server via unix socket, e.g. on-box socket when running on SR Linux itself.
+// Scheme is set to http by default, unless set by WithOptScheme(), host and port are used only to build URL.
+// Socket isn't applied to the transport set by WithOptTransport() or WithOptHTTPClient().
+func WithOptUnixSocket(path string) ClientOption {
+	return func(c *JSONRPCClient) error {
+		if path == "" {
+			return apierr.NewClientError(apierr.CodeClntEndpoint, fmt.Errorf("unix socket path is empty"))
+		}
+		c.target.socket = path
+		return nil
+	}
+}
+
+// Helper method to populate default values of the endpoint and build URL.
+func (c *JSONRPCClient) populateEndpoint() {
+	if c.target.scheme == "" {
+		c.target.scheme = DefaultScheme
+		if c.target.socket != "" {
+			c.target.scheme = "http"
+		}
+	}
+	if c.target.port == nil {
+		defPort := 443
+		if c.target.scheme == "http" {
+			defPort = 80
+		}
+		c.target.port = &defPort
+	}
+	if c.target.path == "" {
+		c.target.path = DefaultURLPath
+	}
+	c.target.url = c.Endpoint().URL()
+}
+
+// Helper method to dial unix socket of the endpoint.
+func (c *JSONRPCClient) dialSocket(ctx context.Context, _, _ string) (net.Conn, error) {
+	var d net.Dialer
+	return d.DialContext(ctx, "unix", c.target.socket)
+}
@@ -0,0 +1,108 @@
+//go:build unit
+
+package srljrpc_test
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/azyablov/srljrpc"
+	"github.com/azyablov/srljrpc/apierr"
+	"github.com/azyablov/srljrpc/srljrpctest"
+)
+
+func TestEndpointURL(t *testing.T) {
+	var testData = []struct {
+		testName string
+		e        srljrpc.Endpoint
+		expURL   string
+	}{
+		{"Host name", srljrpc.Endpoint{Scheme: "https", Host: "leaf1", Port: 443, Path: "/jsonrpc"}, "https://leaf1:443/jsonrpc"},
+		{"IPv4 and HTTP", srljrpc.Endpoint{Scheme: "http", Host: "10.0.0.1", Port: 80, Path: "/jsonrpc"}, "http://10.0.0.1:80/jsonrpc"},
+		{"IPv6", srljrpc.Endpoint{Scheme: "https", Host: "2001:db8::1", Port: 8443, Path: "/jsonrpc"}, "https://[2001:db8::1]:8443/jsonrpc"},
+		{"IPv6 with zone", srljrpc.Endpoint{Scheme: "https", Host: "fe80::1%mgmt0", Port: 443, Path: "/jsonrpc"}, "https://[fe80::1%25mgmt0]:443/jsonrpc"},
+		{"Custom path", srljrpc.Endpoint{Scheme: "https", Host: "leaf1", Port: 443, Path: "/api/v1/jsonrpc"}, "https://leaf1:443/api/v1/jsonrpc"},
+	}
+	for _, td := range testData {
+		t.Run(td.testName, func(t *testing.T) {
+			if u := td.e.URL(); u != td.expURL {
+				t.Errorf("expected URL %s, got %s", td.expURL, u)
+			}
+		})
+	}
+}
+
+func TestEndpointOptions(t *testing.T) {
+	s, c := helperSimClient(t)
+	exp := srljrpc.Endpoint{Scheme: "https", Host: s.Host, Port: s.Port, Path: "/jsonrpc"}
+	if c.Endpoint() != exp {
+		t.Errorf("expected endpoint %+v, got %+v", exp, c.Endpoint())
+	}
+
+	// plain HTTP with custom URL path
+	mux := http.NewServeMux()
+	mux.Handle("/api/jsonrpc", http.StripPrefix("/api", s.Config.Handler))
+	hs := httptest.NewServer(mux)
+	t.Cleanup(hs.Close)
+	u, _ := url.Parse(hs.URL)
+	port, _ := strconv.Atoi(u.Port())
+	host := u.Hostname()
+	c, err := srljrpc.NewJSONRPCClient(&host, append(s.ClientOptions(),
+		srljrpc.WithOptPort(&port), srljrpc.WithOptScheme("http"), srljrpc.WithOptURLPath("/api/jsonrpc"))...)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.GetHostname() != srljrpctest.DefaultHostname {
+		t.Errorf("expected hostname %s, got %s", srljrpctest.DefaultHostname, c.GetHostname())
+	}
+
+	// IPv6 literal in brackets
+	if ln6, err := net.Listen("tcp6", "[::1]:0"); err == nil {
+		hs6 := &httptest.Server{Listener: ln6, Config: &http.Server{Handler: s.Config.Handler}}
+		hs6.Start()
+		t.Cleanup(hs6.Close)
+		port6 := ln6.Addr().(*net.TCPAddr).Port
+		host6 := "[::1]"
+		c, err := srljrpc.NewJSONRPCClient(&host6, append(s.ClientOptions(), srljrpc.WithOptPort(&port6), srljrpc.WithOptScheme("http"))...)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if c.Endpoint().Host != "::1" || c.Endpoint().URL() != "http://[::1]:"+strconv.Itoa(port6)+"/jsonrpc" {
+			t.Errorf("unexpected IPv6 endpoint %+v", c.Endpoint())
+		}
+	}
+
+	// unix socket
+	sock := filepath.Join(t.TempDir(), "jsonrpc.sock")
+	ln, err := net.Listen("unix", sock)
+	if err != nil {
+		t.Skipf("unix sockets aren't supported: %v", err)
+	}
+	us := &http.Server{Handler: s.Config.Handler}
+	go us.Serve(ln)
+	t.Cleanup(func() { us.Close() })
+	host = "localhost"
+	c, err = srljrpc.NewJSONRPCClient(&host, append(s.ClientOptions(), srljrpc.WithOptUnixSocket(sock))...)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	exp = srljrpc.Endpoint{Scheme: "http", Host: "localhost", Port: s.Port, Path: "/jsonrpc", Socket: sock}
+	if c.Endpoint() != exp {
+		t.Errorf("expected endpoint %+v, got %+v", exp, c.Endpoint())
+	}
+	if _, err := c.Get("/system/name"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	for _, opt := range []srljrpc.ClientOption{srljrpc.WithOptScheme("ftp"), srljrpc.WithOptURLPath("jsonrpc"), srljrpc.WithOptUnixSocket("")} {
+		if _, err := s.NewClient(opt); !errors.Is(err, apierr.ErrClntEndpoint) {
+			t.Errorf("expected error %v, got %v", apierr.ErrClntEndpoint, err)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+//go:build unit
+
+package srljrpc_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/azyablov/srljrpc"
+	"github.com/azyablov/srljrpc/apierr"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNewJSONRPCError(t *testing.T) {
+	var testData = []struct {
+		testName string
+		code     int
+		message  string
+		data     json.RawMessage
+		exp      *apierr.JSONRPCError
+	}{
+		{testName: "Unknown element",
+			code: -1, message: "Path not valid - unknown element 'invalid'. Options are [name, description]",
+			exp: &apierr.JSONRPCError{Code: -1, Message: "Path not valid - unknown element 'invalid'. Options are [name, description]",
+				Validation: "unknown element 'invalid'. Options are [name, description]"}},
+		{testName: "Invalid value with path",
+			code: -1, message: "Invalid value for path '/interface[name=system0]/admin-state'",
+			exp: &apierr.JSONRPCError{Code: -1, Message: "Invalid value for path '/interface[name=system0]/admin-state'",
+				Path: "/interface[name=system0]/admin-state", Validation: "Invalid value for path '/interface[name=system0]/admin-state'"}},
+		{testName: "Validation failed",
+			code: -1, message: "Error in path: .interface{.name==\"ethernet-1/1\"}.mtu Validation failed: value 10 out of range",
+			exp: &apierr.JSONRPCError{Code: -1, Message: "Error in path: .interface{.name==\"ethernet-1/1\"}.mtu Validation failed: value 10 out of range",
+				Path: ".interface{.name==\"ethernet-1/1\"}.mtu", Validation: "value 10 out of range"}},
+		{testName: "Validation failed after multibyte text",
+			code: -1, message: strings.Repeat("Ⱥ", 8) + " validation failed: description ÄÖÜ too long",
+			exp: &apierr.JSONRPCError{Code: -1, Message: strings.Repeat("Ⱥ", 8) + " validation failed: description ÄÖÜ too long",
+				Validation: "description ÄÖÜ too long"}},
+		{testName: "Commit conflict",
+			code: -1, message: "Failed to commit - commit confirmed is already in progress",
+			exp: &apierr.JSONRPCError{Code: -1, Message: "Failed to commit - commit confirmed is already in progress", Conflict: true}},
+		{testName: "Structured data",
+			code: -32602, message: "Invalid params", data: json.RawMessage(`{"path":"/system/name","reason":"bad"}`),
+			exp: &apierr.JSONRPCError{Code: -32602, Message: "Invalid params", Data: json.RawMessage(`{"path":"/system/name","reason":"bad"}`), Path: "/system/name"}},
+		{testName: "String data",
+			code: -1, message: "Server error", data: json.RawMessage(`"details"`),
+			exp: &apierr.JSONRPCError{Code: -1, Message: "Server error", Data: json.RawMessage(`"details"`)}},
+	}
+	for _, td := range testData {
+		t.Run(td.testName, func(t *testing.T) {
+			e := apierr.NewJSONRPCError(td.code, td.message, td.data)
+			if out := cmp.Diff(td.exp, e); out != "" {
+				t.Errorf("unexpected error (-want +got):\n%s", out)
+			}
+		})
+	}
+}
+
+func TestJSONRPCErrorFromServer(t *testing.T) {
+	_, c := helperSimClient(t)
+
+	// Unknown element
+	resp, err := c.Get("/system/invalid")
+	if !errors.Is(err, apierr.ErrClntJSONRPCResp) {
+		t.Fatalf("expected error %v, got %v", apierr.ErrClntJSONRPCResp, err)
+	}
+	var je *apierr.JSONRPCError
+	if !errors.As(err, &je) {
+		t.Fatalf("expected JSONRPCError wrapped, got %v", err)
+	}
+	if je.Code != -1 || je.Message != resp.Error.Message || !je.IsValidation() || je.Conflict {
+		t.Errorf("unexpected JSONRPCError: %+v", je)
+	}
+
+	// Commit conflict while commit confirmed is in progress
+	upd := []srljrpc.PV{{Path: "/interface[name=system0]/description", Value: "test"}}
+	if _, err := c.Update(60, upd...); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, err = c.Update(60, upd...)
+	if !errors.As(err, &je) || !je.Conflict {
+		t.Errorf("expected commit conflict, got %v", err)
+	}
+}
@@ -0,0 +1,173 @@
+package srljrpc
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/azyablov/srljrpc/apierr"
+)
+
+// Paths of STATE datastore fetched by Refresh in a single GET request, order matters.
+var factsPaths = []string{
+	"/system/name/host-name",
+	"/system/information",
+	"/platform/chassis",
+	"/interface[name=mgmt0]/subinterface[index=0]",
+	"/system/license",
+}
+
+// DeviceFacts type to represent facts of the target discovered by Facts() or Refresh().
+// Facts are shared between callers and must not be modified.
+type DeviceFacts struct {
+	Hostname        string
+	Description     string // system description, including loaded software and platform
+	Platform        string // loaded platform parsed from the system description, e.g. 7220 IXR-D2
+	ChassisType     string
+	PartNumber      string
+	SerialNumber    string
+	MACAddress      string
+	SoftwareVersion string  // system version as reported by the target
+	Version         Version // parsed SoftwareVersion, zero value if it can't be parsed
+	LastBooted      time.Time
+	Uptime          time.Duration // at the time of collection
+	MgmtAddresses   []string      // IPv4 and IPv6 prefixes of mgmt0.0 subinterface
+	Licenses        []License
+	Collected       time.Time
+}
+
+// License type to represent the state of the license installed on the target.
+type License struct {
+	ID             string `json:"id"`
+	Description    string `json:"description"`
+	ExpirationDate string `json:"expiration-date"`
+	InUse          bool   `json:"in-use"`
+	Expired        bool   `json:"expired"`
+}
+
+// Facts returns facts of the target cached on the client, facts are fetched on the first call.
+func (c *JSONRPCClient) Facts() (*DeviceFacts, error) {
+	return c.FactsContext(context.Background())
+}
+
+// FactsContext is the same as Facts, but uses the provided context for the request.
+func (c *JSONRPCClient) FactsContext(ctx context.Context) (*DeviceFacts, error) {
+	c.factsMu.Lock()
+	f := c.facts
+	c.factsMu.Unlock()
+	if f != nil {
+		return f, nil
+	}
+	return c.RefreshContext(ctx)
+}
+
+// Refresh fetches facts of the target in a single GET request against STATE datastore and updates the cache.
+func (c *JSONRPCClient) Refresh() (*DeviceFacts, error) {
+	return c.RefreshContext(context.Background())
+}
+
+// RefreshContext is the same as Refresh, but uses the provided context for the request.
+func (c *JSONRPCClient) RefreshContext(ctx context.Context) (*DeviceFacts, error) {
+	resp, err := c.StateContext(ctx, factsPaths...)
+	if err != nil {
+		return nil, err
+	}
+
+	type address struct {
+		Address []struct {
+			IPPrefix string `json:"ip-prefix"`
+		} `json:"address"`
+	}
+	var (
+		info struct {
+			Description     string `json:"description"`
+			Version         string `json:"version"`
+			CurrentDatetime string `json:"current-datetime"`
+			LastBooted      string `json:"last-booted"`
+		}
+		chassis struct {
+			Type         string `json:"type"`
+			PartNumber   string `json:"part-number"`
+			SerialNumber string `json:"serial-number"`
+			HWMACAddress string `json:"hw-mac-address"`
+			LastBooted   string `json:"last-booted"`
+		}
+		mgmt struct {
+			IPv4 address `json:"ipv4"`
+			IPv6 address `json:"ipv6"`
+		}
+		lic struct {
+			License []License `json:"license"`
+		}
+	)
+	f := &DeviceFacts{Collected: time.Now()}
+	if f.Hostname, err = DecodeResult[string](nil, resp, 0); err != nil {
+		return nil, err
+	}
+	for i, v := range []interface{}{&info, &chassis, &mgmt, &lic} {
+		if err := decodeFact(resp, i+1, v); err != nil {
+			return nil, err
+		}
+	}
+
+	f.Description = info.Description
+	f.Platform = parsePlatform(info.Description)
+	f.ChassisType = chassis.Type
+	f.PartNumber = chassis.PartNumber
+	f.SerialNumber = chassis.SerialNumber
+	f.MACAddress = chassis.HWMACAddress
+	f.SoftwareVersion = info.Version
+	if v, err := ParseVersion(info.Version); err == nil {
+		f.Version = v
+	}
+	lb := info.LastBooted
+	if lb == "" {
+		lb = chassis.LastBooted
+	}
+	if f.LastBooted, err = time.Parse(time.RFC3339, lb); err == nil {
+		now := f.Collected
+		if t, err := time.Parse(time.RFC3339, info.CurrentDatetime); err == nil {
+			now = t
+		}
+		f.Uptime = now.Sub(f.LastBooted)
+	}
+	for _, a := range append(mgmt.IPv4.Address, mgmt.IPv6.Address...) {
+		f.MgmtAddresses = append(f.MgmtAddresses, a.IPPrefix)
+	}
+	f.Licenses = lic.License
+
+	c.factsMu.Lock()
+	c.facts = f
+	c.factsMu.Unlock()
+	return f, nil
+}
+
+// Helper function to decode the result of the facts command with module prefixes stripped into v.
+func decodeFact(resp *Response, n int, v interface{}) error {
+	tree, err := DecodeResult[interface{}](nil, resp, n)
+	if err != nil {
+		return err
+	}
+	b, err := json.Marshal(stripModules(tree))
+	if err == nil {
+		err = json.Unmarshal(b, v)
+	}
+	if err != nil {
+		return &ResultError{Index: n, Path: factsPaths[n], Err: apierr.NewClientError(apierr.CodeClntResultDecoding, err)}
+	}
+	return nil
+}
+
+// Helper function to parse the loaded platform from the system description,
+// e.g. "SRLinux-v23.3.1-343-gab924f2e64 7220 IXR-D2 Copyright (c) 2000-2020 Nokia. Kernel 5.15.0 ..." gives "7220 IXR-D2".
+func parsePlatform(desc string) string {
+	rest, ok := strings.CutPrefix(desc, "SRLinux-")
+	if !ok {
+		return ""
+	}
+	// skipping loaded software version
+	_, rest, _ = strings.Cut(rest, " ")
+	rest, _, _ = strings.Cut(rest, " Copyright")
+	return strings.TrimSpace(rest)
+}
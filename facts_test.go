@@ -0,0 +1,127 @@
+//go:build unit
+
+package srljrpc_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/azyablov/srljrpc"
+	"github.com/azyablov/srljrpc/apierr"
+	"github.com/azyablov/srljrpc/srljrpctest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestFacts(t *testing.T) {
+	s, c := helperSimClient(t, srljrpctest.WithState(map[string]interface{}{
+		"system": map[string]interface{}{
+			"information": map[string]interface{}{
+				"current-datetime": "2023-05-01T12:00:00Z",
+				"last-booted":      "2023-05-01T10:30:00Z",
+			},
+			"license": []interface{}{
+				map[string]interface{}{"id": "lab", "description": "Lab license", "expiration-date": "2024-05-01T00:00:00Z", "in-use": true, "expired": false},
+			},
+		},
+		"interface": []interface{}{
+			map[string]interface{}{"name": "mgmt0", "subinterface": []interface{}{
+				map[string]interface{}{"index": 0, "ipv4": map[string]interface{}{"address": []interface{}{map[string]interface{}{"ip-prefix": "172.20.20.2/24"}}},
+					"ipv6": map[string]interface{}{"address": []interface{}{map[string]interface{}{"ip-prefix": "2001:172:20:20::2/64"}}}},
+			}},
+		},
+	}))
+
+	f, err := c.Facts()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	exp := &srljrpc.DeviceFacts{
+		Hostname:        srljrpctest.DefaultHostname,
+		Description:     "SRLinux-" + srljrpctest.DefaultVersion + " " + srljrpctest.DefaultChassis + " Copyright (c) 2000-2023 Nokia. Kernel simulator",
+		Platform:        srljrpctest.DefaultChassis,
+		ChassisType:     srljrpctest.DefaultChassis,
+		PartNumber:      "3HE00000AA",
+		SerialNumber:    "SIM0000001",
+		MACAddress:      "1A:00:00:00:00:00",
+		SoftwareVersion: srljrpctest.DefaultVersion,
+		Version:         srljrpc.Version{Major: 23, Minor: 3, Patch: 1, Build: 343, Commit: "gab924f2e64"},
+		LastBooted:      time.Date(2023, 5, 1, 10, 30, 0, 0, time.UTC),
+		Uptime:          90 * time.Minute,
+		MgmtAddresses:   []string{"172.20.20.2/24", "2001:172:20:20::2/64"},
+		Licenses:        []srljrpc.License{{ID: "lab", Description: "Lab license", ExpirationDate: "2024-05-01T00:00:00Z", InUse: true}},
+	}
+	if out := cmp.Diff(exp, f, cmpopts.IgnoreFields(srljrpc.DeviceFacts{}, "Collected")); out != "" {
+		t.Errorf("unexpected facts (-want +got):\n%s", out)
+	}
+
+	// cached until refreshed
+	before := s.Requests()
+	if cf, err := c.Facts(); err != nil || cf != f || s.Requests() != before {
+		t.Errorf("expected cached facts w/o requests, got %v, %v", cf, err)
+	}
+	if err := s.Set("state", "/system/information/version", "v23.10.1-218-ga3fc1bea5a"); err != nil {
+		t.Fatal(err)
+	}
+	rf, err := c.Refresh()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rf.Version.Compare(f.Version) <= 0 || rf.Version.String() != "v23.10.1-218-ga3fc1bea5a" {
+		t.Errorf("expected newer version after refresh, got %s", rf.Version)
+	}
+	if cf, _ := c.Facts(); cf != rf {
+		t.Errorf("expected refreshed facts cached")
+	}
+
+	if err := s.Set("state", "/system/information/version", "unknown"); err != nil {
+		t.Fatal(err)
+	}
+	// facts are collected even if the version can't be parsed
+	uf, err := c.Refresh()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if uf.SoftwareVersion != "unknown" || uf.Version != (srljrpc.Version{}) {
+		t.Errorf("expected raw version w/o parsed one, got %q, %v", uf.SoftwareVersion, uf.Version)
+	}
+}
+
+func TestVersion(t *testing.T) {
+	var testData = []struct {
+		testName string
+		s        string
+		exp      srljrpc.Version
+		expStr   string
+		expErr   error
+	}{
+		{"Full", "v23.3.1-343-gab924f2e64", srljrpc.Version{Major: 23, Minor: 3, Patch: 1, Build: 343, Commit: "gab924f2e64"}, "v23.3.1-343-gab924f2e64", nil},
+		{"Release", "v23.10.1", srljrpc.Version{Major: 23, Minor: 10, Patch: 1}, "v23.10.1", nil},
+		{"Major and minor w/o v", "22.11", srljrpc.Version{Major: 22, Minor: 11}, "v22.11.0", nil},
+		{"Invalid", "latest", srljrpc.Version{}, "", apierr.ErrClntVersionParsing},
+	}
+	for _, td := range testData {
+		t.Run(td.testName, func(t *testing.T) {
+			v, err := srljrpc.ParseVersion(td.s)
+			if !errors.Is(err, td.expErr) {
+				t.Fatalf("expected error %v, got %v", td.expErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if v != td.exp || v.String() != td.expStr {
+				t.Errorf("expected %+v (%s), got %+v (%s)", td.exp, td.expStr, v, v)
+			}
+		})
+	}
+
+	order := []string{"v21.11.3", "v22.11.1", "v23.3.1", "v23.3.1-343-gab924f2e64", "v23.3.2", "v23.10.1"}
+	for i := 1; i < len(order); i++ {
+		a, _ := srljrpc.ParseVersion(order[i-1])
+		b, _ := srljrpc.ParseVersion(order[i])
+		if a.Compare(b) != -1 || b.Compare(a) != 1 || a.Compare(a) != 0 {
+			t.Errorf("unexpected comparison of %s and %s", a, b)
+		}
+	}
+}
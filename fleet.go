@@ -0,0 +1,254 @@
+package srljrpc
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/azyablov/srljrpc/apierr"
+	"github.com/azyablov/srljrpc/formats"
+	"github.com/azyablov/srljrpc/yms"
+)
+
+// Default number of targets processed by the Fleet concurrently.
+const defFleetConcurrency = 16
+
+// FleetTarget type to represent a target specification used to build a Fleet: host and ClientOptions applied in order of appearance.
+type FleetTarget struct {
+	Host string
+	Opts []ClientOption
+}
+
+// FleetOption is a function type that applies options to a Fleet object.
+type FleetOption func(*Fleet) error
+
+// FleetFunc is a function type executed by the Fleet against every client.
+type FleetFunc func(ctx context.Context, c *JSONRPCClient) (*Response, error)
+
+// Fleet type to represent a set of JSON RPC clients, which are used to execute the same request against many targets concurrently.
+type Fleet struct {
+	clients     []*JSONRPCClient
+	concurrency int
+}
+
+// FleetResult type to represent a result of the execution against a single target.
+//
+//	Target is the host used to create the client.
+//	Hostname is the hostname of the target after verification, empty if client creation failed.
+//	Response and Err are returned by the client for the target.
+//	Duration is the time spent to get the response.
+type FleetResult struct {
+	Target   string
+	Hostname string
+	Response *Response
+	Err      error
+	Duration time.Duration
+}
+
+// FleetReport type to represent per-target results in order of clients in the Fleet and summary of successes and failures.
+type FleetReport struct {
+	Results   []FleetResult
+	Succeeded int
+	Failed    int
+}
+
+// Creates a new Fleet from JSON RPC clients and applies options in order of appearance.
+func NewFleet(clients []*JSONRPCClient, opts ...FleetOption) (*Fleet, error) {
+	if len(clients) == 0 {
+		return nil, apierr.NewClientError(apierr.CodeClntFleetNoClients, nil)
+	}
+	for _, c := range clients {
+		if c == nil {
+			return nil, apierr.NewClientError(apierr.CodeClntFleetNoClients, nil)
+		}
+	}
+	f := &Fleet{
+		clients:     clients,
+		concurrency: defFleetConcurrency,
+	}
+	for _, opt := range opts {
+		if err := opt(f); err != nil {
+			return nil, err
+		}
+	}
+	return f, nil
+}
+
+// Creates a new Fleet from target specifications. Clients are created concurrently and respect the Fleet concurrency.
+// Fleet is built from all successfully created clients, while FleetReport provides per-target creation results.
+// Error is returned if none of the clients could be created.
+func NewFleetFromTargets(ctx context.Context, targets []FleetTarget, opts ...FleetOption) (*Fleet, *FleetReport, error) {
+	if len(targets) == 0 {
+		return nil, nil, apierr.NewClientError(apierr.CodeClntFleetNoClients, nil)
+	}
+	// applying options to the temporary fleet to get concurrency
+	tf := &Fleet{concurrency: defFleetConcurrency}
+	for _, opt := range opts {
+		if err := opt(tf); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	clients := make([]*JSONRPCClient, len(targets))
+	base := func(i int) FleetResult {
+		return FleetResult{Target: targets[i].Host}
+	}
+	rep := runBounded(ctx, len(targets), tf.concurrency, base, func(i int) FleetResult {
+		host := targets[i].Host
+		start := time.Now()
+		c, err := NewJSONRPCClientContext(ctx, &host, targets[i].Opts...)
+		res := FleetResult{Target: host, Err: err, Duration: time.Since(start)}
+		if err == nil {
+			clients[i] = c
+			res.Hostname = c.GetHostname()
+		}
+		return res
+	})
+
+	var created []*JSONRPCClient
+	for _, c := range clients {
+		if c != nil {
+			created = append(created, c)
+		}
+	}
+	if len(created) == 0 {
+		return nil, rep, apierr.NewClientError(apierr.CodeClntFleetNoClients, rep.Err())
+	}
+	tf.clients = created
+	return tf, rep, nil
+}
+
+// FleetOption to set maximum number of targets processed concurrently.
+func WithFleetConcurrency(n int) FleetOption {
+	return func(f *Fleet) error {
+		if n <= 0 {
+			return apierr.NewClientError(apierr.CodeClntFleetConcurrency, nil)
+		}
+		f.concurrency = n
+		return nil
+	}
+}
+
+// Clients returns clients of the Fleet.
+func (f *Fleet) Clients() []*JSONRPCClient {
+	return f.clients
+}
+
+// Len returns number of clients in the Fleet.
+func (f *Fleet) Len() int {
+	return len(f.clients)
+}
+
+// Do executes the Requester against all targets of the Fleet with bounded concurrency.
+func (f *Fleet) Do(ctx context.Context, r Requester) *FleetReport {
+	return f.Run(ctx, func(ctx context.Context, c *JSONRPCClient) (*Response, error) {
+		return c.DoContext(ctx, r)
+	})
+}
+
+// Run executes FleetFunc against all clients of the Fleet with bounded concurrency, facilitates Do and other Fleet methods.
+// Clients not started before the context is done aren't called and fail with error matching apierr.ErrClntCtxDone.
+func (f *Fleet) Run(ctx context.Context, fn FleetFunc) *FleetReport {
+	base := func(i int) FleetResult {
+		c := f.clients[i]
+		return FleetResult{Target: *c.target.host, Hostname: c.GetHostname()}
+	}
+	return runBounded(ctx, len(f.clients), f.concurrency, base, func(i int) FleetResult {
+		res := base(i)
+		start := time.Now()
+		res.Response, res.Err = fn(ctx, f.clients[i])
+		res.Duration = time.Since(start)
+		return res
+	})
+}
+
+// Get executes a GET request against RUNNING datastore of all targets.
+func (f *Fleet) Get(ctx context.Context, paths ...string) *FleetReport {
+	return f.Run(ctx, func(ctx context.Context, c *JSONRPCClient) (*Response, error) {
+		return c.GetContext(ctx, paths...)
+	})
+}
+
+// State executes a GET request against STATE datastore of all targets.
+func (f *Fleet) State(ctx context.Context, paths ...string) *FleetReport {
+	return f.Run(ctx, func(ctx context.Context, c *JSONRPCClient) (*Response, error) {
+		return c.StateContext(ctx, paths...)
+	})
+}
+
+// BulkSet executes a SET method with REPLACE/UPDATE/DELETE action request against CANDIDATE datastore of all targets.
+// Parameters are the same as for JSONRPCClient.BulkSet().
+func (f *Fleet) BulkSet(ctx context.Context, delete []PV, replace []PV, update []PV, ym yms.EnumYmType, ct int) *FleetReport {
+	return f.Run(ctx, func(ctx context.Context, c *JSONRPCClient) (*Response, error) {
+		return c.BulkSetContext(ctx, delete, replace, update, ym, ct)
+	})
+}
+
+// CLI executes CLI commands against all targets.
+func (f *Fleet) CLI(ctx context.Context, cmds []string, of formats.EnumOutputFormats) *FleetReport {
+	return f.Run(ctx, func(ctx context.Context, c *JSONRPCClient) (*Response, error) {
+		return c.CLIContext(ctx, cmds, of)
+	})
+}
+
+// Failures returns results of the failed targets.
+func (r *FleetReport) Failures() []FleetResult {
+	var fr []FleetResult
+	for _, res := range r.Results {
+		if res.Err != nil {
+			fr = append(fr, res)
+		}
+	}
+	return fr
+}
+
+// Err returns nil if all targets succeeded, otherwise ClientError with code CodeClntFleetTargetFailed wrapping the error of the first failed target.
+func (r *FleetReport) Err() error {
+	for _, res := range r.Results {
+		if res.Err != nil {
+			return apierr.NewClientError(apierr.CodeClntFleetTargetFailed, res.Err)
+		}
+	}
+	return nil
+}
+
+// Helper function executing fn for indexes [0, n) with at most c goroutines and collecting results in order of indexes.
+// Once the context is done, fn isn't executed for the rest of indexes, their results are made by base with error matching apierr.ErrClntCtxDone.
+func runBounded(ctx context.Context, n int, c int, base func(i int) FleetResult, fn func(i int) FleetResult) *FleetReport {
+	rep := &FleetReport{Results: make([]FleetResult, n)}
+	sem := make(chan struct{}, c)
+	var wg sync.WaitGroup
+	started := 0
+loop:
+	for ; started < n; started++ {
+		if ctx.Err() != nil {
+			break
+		}
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			break loop
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			rep.Results[i] = fn(i)
+		}(started)
+	}
+	for i := started; i < n; i++ {
+		rep.Results[i] = base(i)
+		rep.Results[i].Err = apierr.NewClientError(apierr.CodeClntCtxDone, ctx.Err())
+	}
+	wg.Wait()
+	for _, res := range rep.Results {
+		if res.Err != nil {
+			rep.Failed++
+		} else {
+			rep.Succeeded++
+		}
+	}
+	return rep
+}
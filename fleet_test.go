@@ -0,0 +1,102 @@
+//go:build unit
+
+package srljrpc_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/azyablov/srljrpc"
+	"github.com/azyablov/srljrpc/apierr"
+	"github.com/azyablov/srljrpc/datastores"
+	"github.com/azyablov/srljrpc/formats"
+	"github.com/azyablov/srljrpc/srljrpctest"
+	"github.com/azyablov/srljrpc/yms"
+)
+
+func TestFleet(t *testing.T) {
+	var servers []*srljrpctest.Server
+	var targets []srljrpc.FleetTarget
+	for i := 0; i < 4; i++ {
+		s := srljrpctest.NewServer(srljrpctest.WithHostname(fmt.Sprintf("leaf%d", i+1)))
+		defer s.Close()
+		servers = append(servers, s)
+		targets = append(targets, srljrpc.FleetTarget{Host: s.Host, Opts: s.ClientOptions()})
+	}
+	// Unreachable target
+	down := srljrpctest.NewServer()
+	down.Close()
+	targets = append(targets, srljrpc.FleetTarget{Host: down.Host, Opts: down.ClientOptions()})
+
+	f, rep, err := srljrpc.NewFleetFromTargets(context.Background(), targets, srljrpc.WithFleetConcurrency(2))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if f.Len() != 4 || rep.Succeeded != 4 || rep.Failed != 1 {
+		t.Fatalf("expected 4 clients and 1 failure, got %d clients, %d/%d succeeded/failed", f.Len(), rep.Succeeded, rep.Failed)
+	}
+	if fr := rep.Failures(); len(fr) != 1 || !errors.Is(fr[0].Err, apierr.ErrClntTargetVerification) {
+		t.Fatalf("unexpected failures: %v", fr)
+	}
+	if !errors.Is(rep.Err(), apierr.ErrClntFleetTargetFailed) {
+		t.Errorf("expected error %v, got %v", apierr.ErrClntFleetTargetFailed, rep.Err())
+	}
+
+	// BulkSet against all targets
+	upd := []srljrpc.PV{{Path: "/interface[name=system0]/description", Value: srljrpc.CommandValue("FLEET")}}
+	rep = f.BulkSet(context.Background(), nil, nil, upd, yms.SRL, 0)
+	if rep.Err() != nil || rep.Succeeded != 4 {
+		t.Fatalf("unexpected fleet BulkSet result: %v", rep.Err())
+	}
+	for i, s := range servers {
+		v, err := s.Get("running", "/interface[name=system0]/description")
+		if err != nil || v != "FLEET" {
+			t.Errorf("server %d: expected FLEET, got %v, %v", i, v, err)
+		}
+	}
+
+	// Do with the same request against all targets, results are in order of clients
+	r, err := srljrpc.NewGetRequest([]string{"/system/name/host-name"}, false, false, formats.JSON, datastores.RUNNING)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rep = f.Do(context.Background(), r)
+	for i, res := range rep.Results {
+		if res.Err != nil || res.Hostname != fmt.Sprintf("leaf%d", i+1) || string(res.Response.Result) != fmt.Sprintf(`["leaf%d"]`, i+1) {
+			t.Errorf("unexpected result for %s: %v, %v", res.Target, res.Response, res.Err)
+		}
+	}
+
+	// Targets not started before the context is done
+	sf, err := srljrpc.NewFleet(f.Clients(), srljrpc.WithFleetConcurrency(1))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	rep = sf.Run(ctx, func(ctx context.Context, c *srljrpc.JSONRPCClient) (*srljrpc.Response, error) {
+		cancel()
+		return nil, nil
+	})
+	if rep.Succeeded != 1 || rep.Failed != 3 {
+		t.Fatalf("expected 1 started target, got %d/%d succeeded/failed", rep.Succeeded, rep.Failed)
+	}
+	for i, res := range rep.Results[1:] {
+		if !errors.Is(res.Err, apierr.ErrClntCtxDone) || res.Hostname != fmt.Sprintf("leaf%d", i+2) {
+			t.Errorf("unexpected result for %s: %v, %v", res.Target, res.Hostname, res.Err)
+		}
+	}
+	before := servers[0].Requests()
+	if rep = f.Get(ctx, "/system/name/host-name"); rep.Failed != 4 || servers[0].Requests() != before {
+		t.Errorf("expected all targets failed w/o requests, got %d failed", rep.Failed)
+	}
+
+	// Options validation
+	if _, err := srljrpc.NewFleet(nil); !errors.Is(err, apierr.ErrClntFleetNoClients) {
+		t.Errorf("expected error %v, got %v", apierr.ErrClntFleetNoClients, err)
+	}
+	if _, err := srljrpc.NewFleet(f.Clients(), srljrpc.WithFleetConcurrency(0)); !errors.Is(err, apierr.ErrClntFleetConcurrency) {
+		t.Errorf("expected error %v, got %v", apierr.ErrClntFleetConcurrency, err)
+	}
+}
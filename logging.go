@@ -0,0 +1,114 @@
+package srljrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/azyablov/srljrpc/apierr"
+)
+
+// LogOption is a function type to apply options to the logging of JSON RPC exchanges.
+type LogOption func(*logOpts)
+
+// logOpts type to represent options of the logging.
+type logOpts struct {
+	ok      slog.Level
+	failed  slog.Level
+	payload bool
+}
+
+// WithLogLevels sets levels of the records for successful and failed requests, slog.LevelInfo and slog.LevelError by default.
+func WithLogLevels(ok, failed slog.Level) LogOption {
+	return func(o *logOpts) {
+		o.ok = ok
+		o.failed = failed
+	}
+}
+
+// WithLogPayload enables additional records with full request and response payloads at slog.LevelDebug.
+// Values of secret-looking leaves (password, secret, token) are redacted.
+func WithLogPayload() LogOption {
+	return func(o *logOpts) {
+		o.payload = true
+	}
+}
+
+// ClientOption to log every attempt to call the JSON RPC server via Do and the methods based on it, including target verification.
+// Records contain target, method, datastore, command paths, request ID, latency, HTTP status and RPC error message.
+func WithOptLogger(l *slog.Logger, opts ...LogOption) ClientOption {
+	return func(c *JSONRPCClient) error {
+		if l == nil {
+			return apierr.NewClientError(apierr.CodeClntLoggerIsNil, nil)
+		}
+		lo := &logOpts{ok: slog.LevelInfo, failed: slog.LevelError}
+		for _, o := range opts {
+			o(lo)
+		}
+		c.logger = l
+		c.logOpts = lo
+		return nil
+	}
+}
+
+// Helper function to redact value suffixes of the paths pointing to secret-looking leaves.
+func redactPaths(ps []string) []string {
+	r := make([]string, len(ps))
+	for i, p := range ps {
+		r[i], _ = redactPath(p)
+	}
+	return r
+}
+
+// Middleware logging a single attempt to call the JSON RPC server, the innermost one in the chain.
+func (c *JSONRPCClient) logging(next Handler) Handler {
+	return func(ctx context.Context, r Requester) (*Response, error) {
+		start := time.Now()
+		resp, err := next(ctx, r)
+		latency := time.Since(start)
+
+		ri := describeRequest(r)
+		attrs := []slog.Attr{
+			slog.String("target", *c.target.host),
+			slog.String("method", ri.method),
+			slog.String("datastore", ri.datastore),
+			slog.Any("paths", redactPaths(ri.paths)),
+			slog.Int("id", r.GetID()),
+			slog.Duration("latency", latency),
+		}
+		var se *HTTPStatusError
+		switch {
+		case errors.As(err, &se):
+			attrs = append(attrs, slog.Int("status", se.StatusCode))
+		case resp != nil, err == nil:
+			attrs = append(attrs, slog.Int("status", http.StatusOK))
+		}
+		if resp != nil && resp.Error != nil {
+			attrs = append(attrs, slog.String("rpc_error", resp.Error.Message))
+		}
+		level := c.logOpts.ok
+		if err != nil {
+			level = c.logOpts.failed
+			attrs = append(attrs, slog.String("error", err.Error()))
+		}
+		c.logger.LogAttrs(ctx, level, "json rpc request", attrs...)
+
+		if c.logOpts.payload && c.logger.Enabled(ctx, slog.LevelDebug) {
+			req, _ := r.Marshal()
+			attrs := []slog.Attr{slog.Int("id", r.GetID()), slog.Any("request", redactJSON(req))}
+			if resp != nil {
+				b, _ := resp.Marshal()
+				attrs = append(attrs, slog.Any("response", redactResponse(req, b)))
+			}
+			if br, ok := r.(*batchRequest); ok && err == nil {
+				b, _ := json.Marshal(br.resps)
+				attrs = append(attrs, slog.Any("response", redactResponse(req, b)))
+			}
+			c.logger.LogAttrs(ctx, slog.LevelDebug, "json rpc payload", attrs...)
+		}
+		return resp, err
+	}
+}
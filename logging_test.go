@@ -0,0 +1,103 @@
+//go:build unit
+
+package srljrpc_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/azyablov/srljrpc"
+	"github.com/azyablov/srljrpc/actions"
+	"github.com/azyablov/srljrpc/apierr"
+	"github.com/azyablov/srljrpc/methods"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLogger(t *testing.T) {
+	s, _ := helperSimClient(t)
+	var buf bytes.Buffer
+	l := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	c, err := s.NewClient(srljrpc.WithOptLogger(l, srljrpc.WithLogLevels(slog.LevelDebug, slog.LevelWarn), srljrpc.WithLogPayload()))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	buf.Reset()
+	pwPath := "/system/aaa/authentication/user[username=netops]/password"
+	if _, err := c.Update(0, srljrpc.PV{Path: pwPath, Value: "s3cr3t"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := c.Update(0, srljrpc.PV{Path: pwPath + ":hunter2"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := c.Get("/system/invalid"); err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+	// get w/o datastore is served from running
+	cmd, err := srljrpc.NewCommand(actions.NONE, "/system/name/host-name", srljrpc.CommandValue(""))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	r, err := srljrpc.NewRequest(methods.GET, []*srljrpc.Command{cmd})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := c.Do(r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(buf.String(), "s3cr3t") || strings.Contains(buf.String(), "hunter2") {
+		t.Errorf("password isn't redacted:\n%s", buf.String())
+	}
+
+	type record struct {
+		Level     string
+		Msg       string
+		Method    string
+		Datastore string
+		Paths     []string
+		Status    int
+		RPCError  string `json:"rpc_error"`
+		Request   json.RawMessage
+		Response  json.RawMessage
+	}
+	var recs []record
+	sc := bufio.NewScanner(&buf)
+	for sc.Scan() {
+		var r record
+		if err := json.Unmarshal(sc.Bytes(), &r); err != nil {
+			t.Fatalf("can't unmarshal record: %v", err)
+		}
+		recs = append(recs, r)
+	}
+	if len(recs) != 8 {
+		t.Fatalf("expected 8 records, got %d:\n%s", len(recs), buf.String())
+	}
+	exp := []record{
+		{Level: "DEBUG", Msg: "json rpc request", Method: "set", Datastore: "candidate", Paths: []string{pwPath}, Status: 200},
+		{Level: "DEBUG", Msg: "json rpc payload"},
+		{Level: "DEBUG", Msg: "json rpc request", Method: "set", Datastore: "candidate", Paths: []string{pwPath + ":" + srljrpc.Redacted}, Status: 200},
+		{Level: "DEBUG", Msg: "json rpc payload"},
+		{Level: "WARN", Msg: "json rpc request", Method: "get", Datastore: "running", Paths: []string{"/system/invalid"}, Status: 200, RPCError: recs[4].RPCError},
+		{Level: "DEBUG", Msg: "json rpc payload"},
+		{Level: "DEBUG", Msg: "json rpc request", Method: "get", Datastore: "running", Paths: []string{"/system/name/host-name"}, Status: 200},
+		{Level: "DEBUG", Msg: "json rpc payload"},
+	}
+	if out := cmp.Diff(exp, recs, cmp.FilterPath(func(p cmp.Path) bool {
+		f := p.Last().String()
+		return f == ".Request" || f == ".Response"
+	}, cmp.Ignore())); out != "" {
+		t.Errorf("unexpected records (-want +got):\n%s", out)
+	}
+	if recs[4].RPCError == "" || len(recs[1].Request) == 0 || len(recs[5].Response) == 0 {
+		t.Errorf("expected RPC error message and payloads, got %+v", recs)
+	}
+
+	if _, err := s.NewClient(srljrpc.WithOptLogger(nil)); !errors.Is(err, apierr.ErrClntLoggerIsNil) {
+		t.Errorf("expected error %v, got %v", apierr.ErrClntLoggerIsNil, err)
+	}
+}
@@ -188,6 +188,67 @@ type Requester interface {
 	SetOutputFormat(of formats.EnumOutputFormats) error
 }
 
+// requestInfo type to represent summary of the request used by logging and instrumentation.
+type requestInfo struct {
+	method    string
+	datastore string
+	ym        string
+	paths     []string // command paths, empty for CLI requests since commands could contain secrets
+	commands  int
+}
+
+// Helper function to describe the request, datastore of the first command is used if not set in params.
+func describeRequest(r Requester) requestInfo {
+	var ri requestInfo
+	if m, err := r.GetMethod(); err == nil {
+		ri.method = string(m)
+	}
+	switch t := r.(type) {
+	case *batchRequest:
+		for _, r := range t.b.requests {
+			bi := describeRequest(r)
+			ri.commands += bi.commands
+			ri.paths = append(ri.paths, bi.paths...)
+		}
+		return ri
+	case *Request:
+		if t.Params == nil {
+			break
+		}
+		ri.commands = len(t.Params.Commands)
+		if t.Params.Datastore != nil {
+			ri.datastore = t.Params.Datastore.Datastore
+		}
+		if t.Params.YmType != nil {
+			ri.ym = t.Params.YmType.YangModels
+		}
+		for _, c := range t.Params.Commands {
+			ri.paths = append(ri.paths, c.Path)
+			if ri.datastore == "" && c.Datastore != nil {
+				ri.datastore = c.Datastore.Datastore
+			}
+		}
+	case *CLIRequest:
+		if t.Params != nil {
+			ri.commands = len(t.Params.Commands)
+		}
+	}
+	// default datastore of the target: running for get, candidate for set, validate and diff
+	if ri.datastore == "" {
+		switch ri.method {
+		case string(methods.CLI):
+		case string(methods.GET):
+			ri.datastore = string(datastores.RUNNING)
+		default:
+			ri.datastore = string(datastores.CANDIDATE)
+		}
+	}
+	if ri.ym == "" && ri.method != string(methods.CLI) {
+		ri.ym = string(yms.SRL)
+	}
+	return ri
+}
+
 // RequestOption is a function type that applies options to a Request.
 // Each RequestOption has validation logic implemented to check correctness of the option application and return non nil apierr.MessageError if the option is not correct.
 type RequestOption func(*Request) error
@@ -215,14 +276,15 @@ func WithConfirmTimeout(t int) RequestOption {
 }
 
 // Defines yang models RequestOption.
+// Allowed for all methods except CLI, e.g. GET with OpenConfig yang models returns configuration in OpenConfig format.
 func WithYmType(ym yms.EnumYmType) RequestOption {
 	return func(r *Request) error {
 		m, err := r.GetMethod()
 		if err != nil {
 			return apierr.NewMessageError(apierr.CodeMsgGettingMethod, err)
 		}
-		// yang models specification on Request.Params level is not supported for method CLI and GET
-		if m == methods.CLI || m == methods.GET {
+		// yang models specification on Request.Params level is not supported for method CLI
+		if m == methods.CLI {
 			return apierr.NewMessageError(apierr.CodeMsgYANGSpecNotAllowed, nil)
 		}
 		err = r.Params.withYmType(ym)
@@ -605,12 +667,19 @@ func (r *CLIRequest) SetOutputFormat(of formats.EnumOutputFormats) error {
 // When a rpc call is made, the Server MUST reply with a Response, except for in the case of Notifications. The Response is expressed as a single JSON Object.
 //
 //	ID should be set to client provided ID.
+//	Code is a number that indicates the error type that occurred.
 //	Message is a string providing a short description of the error. The message SHOULD be limited to a concise single sentence."
 //	Data is a primitive or structured value that contains additional information about the error. This may be omitted. The value of this member is defined by the Server (e.g. detailed error information, nested errors etc.).
 type RpcError struct {
-	ID      int    `json:"id"`
-	Message string `json:"message"`
-	Data    string `json:"data,omitempty"`
+	ID      int             `json:"id"`
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+// Converts RpcError into apierr.JSONRPCError with details parsed from message and data.
+func (e *RpcError) jsonRPCError() error {
+	return apierr.NewJSONRPCError(e.Code, e.Message, e.Data)
 }
 
 // JSON RPC response message. When a rpc call is made, the Server MUST reply with a Response, except for in the case of Notifications. The Response is expressed as a single JSON Object.
@@ -63,6 +63,7 @@ func TestNewRequest_Get(t *testing.T) {
 		{"Basic GET with empty path", cmdResults[5], apierr.ErrMsgReqAddingCmds, `null`, []srljrpc.RequestOption{}},
 		{"Basic GET with request STATE datastore", cmdResults[6], apierr.ErrMsgReqGetDSNotAllowed, `null`, []srljrpc.RequestOption{srljrpc.WithRequestDatastore(datastores.TOOLS)}},
 		{"Basic GET", cmdResults[7], apierr.ErrMsgReqSettingConfirmTimeout, `null`, []srljrpc.RequestOption{srljrpc.WithOutputFormat(formats.JSON), srljrpc.WithConfirmTimeout(5)}},
+		{"Basic GET with OC yang models", cmdResults[0], nil, `{"jsonrpc":"2.0","id":{{.}},"method":"get","params":{"commands":[{"path":"/system/name/host-name"}],"yang-models":"oc"}}`, []srljrpc.RequestOption{srljrpc.WithYmType(yms.OC)}},
 	}
 
 	for _, td := range testData {
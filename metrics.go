@@ -0,0 +1,99 @@
+package srljrpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/azyablov/srljrpc/apierr"
+)
+
+// Outcomes of the request reported by RequestObservation.
+const (
+	OutcomeSuccess  = "success"   // response without error
+	OutcomeRPCError = "rpc_error" // JSON RPC error returned by the target
+	OutcomeError    = "error"     // request failed on client side or HTTP level
+)
+
+// Metrics is the interface implemented by metrics collectors, e.g. srljrpcprom.Metrics, to observe every attempt to call the JSON RPC server.
+// ObserveRequest must be safe for concurrent use.
+type Metrics interface {
+	ObserveRequest(o RequestObservation)
+}
+
+// RequestObservation type to represent the outcome of a single attempt to call the JSON RPC server.
+// Code is apierr code of the returned error, CodeClntUndefined if the request succeeded.
+type RequestObservation struct {
+	Target        string
+	Method        string
+	Datastore     string
+	Outcome       string
+	Code          apierr.EnumCltErr
+	Latency       time.Duration
+	BytesSent     int64
+	BytesReceived int64
+}
+
+// ClientOption to report every attempt to call the JSON RPC server via Do and the methods based on it to the Metrics.
+func WithOptMetrics(m Metrics) ClientOption {
+	return func(c *JSONRPCClient) error {
+		if m == nil {
+			return apierr.NewClientError(apierr.CodeClntMetricsIsNil, nil)
+		}
+		c.metrics = m
+		return nil
+	}
+}
+
+// exchangeStats type to represent HTTP level statistics of the exchange collected by send.
+type exchangeStats struct {
+	sent     int64
+	received int64
+}
+
+type exchangeStatsKey struct{}
+
+// Middleware reporting a single attempt to call the JSON RPC server to the Metrics.
+func (c *JSONRPCClient) instrument(next Handler) Handler {
+	return func(ctx context.Context, r Requester) (*Response, error) {
+		st := &exchangeStats{}
+		start := time.Now()
+		resp, err := next(context.WithValue(ctx, exchangeStatsKey{}, st), r)
+
+		ri := describeRequest(r)
+		o := RequestObservation{
+			Target:        *c.target.host,
+			Method:        ri.method,
+			Datastore:     ri.datastore,
+			Outcome:       OutcomeSuccess,
+			Latency:       time.Since(start),
+			BytesSent:     st.sent,
+			BytesReceived: st.received,
+		}
+		if err != nil {
+			o.Outcome = OutcomeError
+			if errors.Is(err, apierr.ErrClntJSONRPCResp) {
+				o.Outcome = OutcomeRPCError
+			}
+			var ce apierr.ClientError
+			if errors.As(err, &ce) {
+				o.Code = ce.Code
+			}
+		}
+		c.metrics.ObserveRequest(o)
+		return resp, err
+	}
+}
+
+// countingReader type to count bytes read from the HTTP response body.
+type countingReader struct {
+	r io.Reader
+	n *int64
+}
+
+func (cr countingReader) Read(p []byte) (int, error) {
+	n, err := cr.r.Read(p)
+	*cr.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,149 @@
+//go:build unit
+
+package srljrpc_test
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/azyablov/srljrpc"
+	"github.com/azyablov/srljrpc/apierr"
+	"github.com/azyablov/srljrpc/srljrpcprom"
+	"github.com/google/go-cmp/cmp"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// observer collects observations reported by the client.
+type observer struct {
+	mu  sync.Mutex
+	obs []srljrpc.RequestObservation
+}
+
+func (o *observer) ObserveRequest(ro srljrpc.RequestObservation) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.obs = append(o.obs, ro)
+}
+
+// failingRegisterer fails registration of the collector after n registered ones.
+type failingRegisterer struct {
+	prometheus.Registerer
+	n          int
+	registered int
+}
+
+func (r *failingRegisterer) Register(c prometheus.Collector) error {
+	if r.registered == r.n {
+		return errors.New("registration failed")
+	}
+	if err := r.Registerer.Register(c); err != nil {
+		return err
+	}
+	r.registered++
+	return nil
+}
+
+func (r *failingRegisterer) Unregister(c prometheus.Collector) bool {
+	if !r.Registerer.Unregister(c) {
+		return false
+	}
+	r.registered--
+	return true
+}
+
+func TestMetrics(t *testing.T) {
+	s, _ := helperSimClient(t)
+	reg := prometheus.NewRegistry()
+	pm, err := srljrpcprom.New(reg, srljrpcprom.WithTargetLabel())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	o := &observer{}
+	obsClient, err := s.NewClient(srljrpc.WithOptMetrics(o))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	promClient, err := s.NewClient(srljrpc.WithOptMetrics(pm))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, c := range []*srljrpc.JSONRPCClient{obsClient, promClient} {
+		if _, err := c.Get("/system/name"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := c.Get("/system/invalid"); !errors.Is(err, apierr.ErrClntJSONRPCResp) {
+			t.Fatalf("expected error %v, got %v", apierr.ErrClntJSONRPCResp, err)
+		}
+	}
+
+	// target verification, get and failed get
+	if len(o.obs) != 3 {
+		t.Fatalf("expected 3 observations, got %d", len(o.obs))
+	}
+	for _, ob := range o.obs {
+		if ob.Target != s.Host || ob.Method != "get" || ob.Latency <= 0 || ob.BytesSent == 0 || ob.BytesReceived == 0 {
+			t.Errorf("unexpected observation: %+v", ob)
+		}
+	}
+	if o.obs[1].Outcome != srljrpc.OutcomeSuccess || o.obs[1].Datastore != "running" || o.obs[1].Code != apierr.CodeClntUndefined {
+		t.Errorf("unexpected successful observation: %+v", o.obs[1])
+	}
+	if o.obs[2].Outcome != srljrpc.OutcomeRPCError || o.obs[2].Code != apierr.CodeClntJSONRPCResp {
+		t.Errorf("unexpected failed observation: %+v", o.obs[2])
+	}
+
+	mfs, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := map[string]float64{}
+	for _, mf := range mfs {
+		for _, m := range mf.GetMetric() {
+			for _, lp := range m.GetLabel() {
+				if code := strconv.Itoa(int(apierr.CodeClntJSONRPCResp)); lp.GetName() == "code" && lp.GetValue() != code {
+					t.Errorf("expected code label %q, got %q", code, lp.GetValue())
+				}
+			}
+			v := m.GetCounter().GetValue()
+			if h := m.GetHistogram(); h != nil {
+				v = float64(h.GetSampleCount())
+			}
+			got[mf.GetName()] += v
+		}
+	}
+	for _, name := range []string{"srljrpc_request_bytes_total", "srljrpc_response_bytes_total"} {
+		if got[name] == 0 {
+			t.Errorf("expected non zero %s", name)
+		}
+		delete(got, name)
+	}
+	exp := map[string]float64{
+		"srljrpc_requests_total":           3,
+		"srljrpc_request_duration_seconds": 3,
+		"srljrpc_errors_total":             1,
+	}
+	if out := cmp.Diff(exp, got); out != "" {
+		t.Errorf("unexpected metrics (-want +got):\n%s", out)
+	}
+
+	if _, err := srljrpcprom.New(reg); err == nil {
+		t.Errorf("expected error registering metrics twice, got nil")
+	}
+	// collectors registered before the failure are unregistered
+	fr := &failingRegisterer{Registerer: prometheus.NewRegistry(), n: 4}
+	if _, err := srljrpcprom.New(fr); err == nil {
+		t.Errorf("expected error registering metrics, got nil")
+	}
+	if fr.registered != 0 {
+		t.Errorf("expected no collectors left registered, got %d", fr.registered)
+	}
+	if _, err := srljrpcprom.New(fr.Registerer); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := s.NewClient(srljrpc.WithOptMetrics(nil)); !errors.Is(err, apierr.ErrClntMetricsIsNil) {
+		t.Errorf("expected error %v, got %v", apierr.ErrClntMetricsIsNil, err)
+	}
+}
@@ -0,0 +1,95 @@
+package srljrpc
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/azyablov/srljrpc/apierr"
+)
+
+// Handler is a function type to call JSON RPC server with the request and return the response.
+type Handler func(ctx context.Context, r Requester) (*Response, error)
+
+// Middleware is a function type to wrap Handler, so logging, metrics, tracing or rate limiting could be injected
+// before the request is sent and after the response is received.
+type Middleware func(next Handler) Handler
+
+// ClientOption to add middlewares around Do and the methods based on it, including target verification.
+// The first middleware is the outermost one, middlewares are called for every attempt if retries are enabled by WithOptRetry().
+func WithOptMiddleware(mws ...Middleware) ClientOption {
+	return func(c *JSONRPCClient) error {
+		for _, mw := range mws {
+			if mw == nil {
+				return apierr.NewClientError(apierr.CodeClntMiddlewareIsNil, nil)
+			}
+		}
+		c.middlewares = append(c.middlewares, mws...)
+		return nil
+	}
+}
+
+// BeforeSend returns Middleware calling f before the request is sent. Returned context is used for the request,
+// while the request isn't sent if f returns error.
+func BeforeSend(f func(ctx context.Context, r Requester) (context.Context, error)) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, r Requester) (*Response, error) {
+			ctx, err := f(ctx, r)
+			if err != nil {
+				return nil, err
+			}
+			return next(ctx, r)
+		}
+	}
+}
+
+// AfterReceive returns Middleware calling f after the response is received or the request failed,
+// error returned by f replaces the original one.
+func AfterReceive(f func(ctx context.Context, r Requester, resp *Response, err error) error) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, r Requester) (*Response, error) {
+			resp, err := next(ctx, r)
+			return resp, f(ctx, r, resp, err)
+		}
+	}
+}
+
+// ClientOption to set HTTP transport instead of the default one, e.g. with proxy or custom dialer.
+// TLS configuration set by WithOptTLS() isn't applied to the transport. Can't be combined with WithOptReplay().
+func WithOptTransport(rt http.RoundTripper) ClientOption {
+	return func(c *JSONRPCClient) error {
+		if rt == nil {
+			return apierr.NewClientError(apierr.CodeClntHTTPClientIsNil, nil)
+		}
+		if c.transport != nil {
+			return apierr.NewClientError(apierr.CodeClntTransportConflict, nil)
+		}
+		c.transport = rt
+		return nil
+	}
+}
+
+// ClientOption to set HTTP client instead of the default one, takes precedence over WithOptTransport().
+// Timeout set by WithOptTimeout() and TLS configuration set by WithOptTLS() aren't applied to the client.
+func WithOptHTTPClient(hc *http.Client) ClientOption {
+	return func(c *JSONRPCClient) error {
+		if hc == nil {
+			return apierr.NewClientError(apierr.CodeClntHTTPClientIsNil, nil)
+		}
+		c.client = hc
+		return nil
+	}
+}
+
+// Helper method to build the chain of middlewares around a single attempt to call the JSON RPC server.
+func (c *JSONRPCClient) buildHandler() {
+	c.handler = c.do
+	if c.metrics != nil {
+		c.handler = c.instrument(c.handler)
+	}
+	if c.logger != nil {
+		c.handler = c.logging(c.handler)
+	}
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		c.handler = c.middlewares[i](c.handler)
+	}
+}
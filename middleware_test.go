@@ -0,0 +1,125 @@
+//go:build unit
+
+package srljrpc_test
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/azyablov/srljrpc"
+	"github.com/azyablov/srljrpc/apierr"
+	"github.com/azyablov/srljrpc/methods"
+	"github.com/google/go-cmp/cmp"
+)
+
+type ctxKey struct{}
+
+func TestMiddleware(t *testing.T) {
+	var calls []string
+	trace := func(name string) srljrpc.Middleware {
+		return func(next srljrpc.Handler) srljrpc.Handler {
+			return func(ctx context.Context, r srljrpc.Requester) (*srljrpc.Response, error) {
+				calls = append(calls, name+" before")
+				resp, err := next(ctx, r)
+				calls = append(calls, name+" after")
+				return resp, err
+			}
+		}
+	}
+	blocked := errors.New("blocked")
+	block := false
+	var method methods.EnumMethods
+	s, _ := helperSimClient(t)
+	c, err := s.NewClient(srljrpc.WithOptMiddleware(
+		trace("outer"),
+		trace("inner"),
+		srljrpc.BeforeSend(func(ctx context.Context, r srljrpc.Requester) (context.Context, error) {
+			if block {
+				return ctx, blocked
+			}
+			return context.WithValue(ctx, ctxKey{}, "value"), nil
+		}),
+		srljrpc.AfterReceive(func(ctx context.Context, r srljrpc.Requester, resp *srljrpc.Response, err error) error {
+			if ctx.Value(ctxKey{}) != "value" {
+				t.Errorf("expected context value propagated by BeforeSend")
+			}
+			method, _ = r.GetMethod()
+			return err
+		}),
+	))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	calls = nil
+	if _, err := c.Get("/system/name"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out := cmp.Diff([]string{"outer before", "inner before", "inner after", "outer after"}, calls); out != "" {
+		t.Errorf("unexpected middleware calls (-want +got):\n%s", out)
+	}
+	if method != methods.GET {
+		t.Errorf("expected method %v, got %v", methods.GET, method)
+	}
+
+	block = true
+	before := s.Requests()
+	if _, err := c.Get("/system/name"); !errors.Is(err, blocked) {
+		t.Errorf("expected error %v, got %v", blocked, err)
+	}
+	if s.Requests() != before {
+		t.Errorf("expected request not sent")
+	}
+
+	if _, err := s.NewClient(srljrpc.WithOptMiddleware(nil)); !errors.Is(err, apierr.ErrClntMiddlewareIsNil) {
+		t.Errorf("expected error %v, got %v", apierr.ErrClntMiddlewareIsNil, err)
+	}
+}
+
+// countingTransport counts HTTP requests sent via the next RoundTripper.
+type countingTransport struct {
+	next http.RoundTripper
+	n    int32
+}
+
+func (ct *countingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	atomic.AddInt32(&ct.n, 1)
+	return ct.next.RoundTrip(r)
+}
+
+func TestCustomTransport(t *testing.T) {
+	s, _ := helperSimClient(t)
+	insecure := &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}
+
+	rt := &countingTransport{next: insecure}
+	c, err := s.NewClient(srljrpc.WithOptTransport(rt))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := c.Get("/system/name"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// target verification and get
+	if n := atomic.LoadInt32(&rt.n); n != 2 {
+		t.Errorf("expected 2 requests via transport, got %d", n)
+	}
+
+	hcRT := &countingTransport{next: insecure}
+	if _, err := s.NewClient(srljrpc.WithOptTransport(rt), srljrpc.WithOptHTTPClient(&http.Client{Transport: hcRT})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := atomic.LoadInt32(&hcRT.n); n != 1 {
+		t.Errorf("expected 1 request via HTTP client, got %d", n)
+	}
+
+	if _, err := s.NewClient(srljrpc.WithOptTransport(nil)); !errors.Is(err, apierr.ErrClntHTTPClientIsNil) {
+		t.Errorf("expected error %v, got %v", apierr.ErrClntHTTPClientIsNil, err)
+	}
+	if _, err := s.NewClient(srljrpc.WithOptHTTPClient(nil)); !errors.Is(err, apierr.ErrClntHTTPClientIsNil) {
+		t.Errorf("expected error %v, got %v", apierr.ErrClntHTTPClientIsNil, err)
+	}
+}
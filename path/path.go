@@ -0,0 +1,297 @@
+// Package path provides parser, renderer and builder of SR Linux / gNMI human-readable paths used in JSON RPC commands,
+// e.g. /srl_nokia-network-instance:network-instance[name="MAC-VRF 1"]/description:value.
+package path
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Key type to represent a single key of the list element.
+type Key struct {
+	Name  string
+	Value string
+}
+
+// Elem type to represent a single element of the path with optional module prefix and list keys.
+type Elem struct {
+	Prefix string
+	Name   string
+	Keys   []Key
+}
+
+// Path type to represent the path with optional value specified as path suffix (e.g. /system/name/host-name:leaf1).
+// Path could be created by Parse() or built programmatically starting with New().
+type Path struct {
+	Elems    []Elem
+	Value    string
+	HasValue bool
+	err      error // first error occurred while building the path
+}
+
+// Creates a new empty (root) Path, which is used as a starting point of the builder.
+func New() *Path {
+	return &Path{}
+}
+
+// Parse parses human-readable path into elements, keys and value (if provided as path suffix).
+//
+// Key values could be quoted, while quote and backslash inside the quoted value must be escaped by backslash.
+// Colon is treated as a module prefix separator only if preceded by module-like name (containing '-' or '_') and followed by identifier,
+// which is followed by '/' or '[' and the rest of the path is valid, otherwise colon starts the value suffix running to the end of the path,
+// which isn't validated. So the module prefix of the last element isn't supported by the parser and should be set by the builder.
+func Parse(s string) (*Path, error) {
+	p := &Path{}
+	if !strings.HasPrefix(s, "/") {
+		return nil, fmt.Errorf("path %q must start with '/'", s)
+	}
+	if s == "/" {
+		return p, nil
+	}
+	var cur Elem
+	var name strings.Builder
+	flush := func() error {
+		cur.Name = name.String()
+		if cur.Name == "" {
+			return fmt.Errorf("empty element in path %q", s)
+		}
+		p.Elems = append(p.Elems, cur)
+		cur = Elem{}
+		name.Reset()
+		return nil
+	}
+	i := 1
+	for i < len(s) {
+		ch := s[i]
+		switch {
+		case ch == '/':
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			i++
+		case ch == '[':
+			if name.Len() == 0 {
+				return nil, fmt.Errorf("key without element name in path %q", s)
+			}
+			k, n, err := parseKey(s, i)
+			if err != nil {
+				return nil, err
+			}
+			cur.Keys = append(cur.Keys, k)
+			i = n
+		case ch == ':':
+			if name.Len() != 0 && len(cur.Keys) == 0 && cur.Prefix == "" && isPrefixColon(s, i, name.String()) {
+				cur.Prefix = name.String()
+				name.Reset()
+				i++
+				continue
+			}
+			p.Value = s[i+1:]
+			p.HasValue = true
+			i = len(s)
+		case ch == ']' || ch == '"' || ch == '=' || ch == ' ':
+			return nil, fmt.Errorf("unexpected character %q in path %q", ch, s)
+		default:
+			if len(cur.Keys) != 0 {
+				return nil, fmt.Errorf("unexpected character %q after keys in path %q", ch, s)
+			}
+			name.WriteByte(ch)
+			i++
+		}
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// MustParse is like Parse, but panics if the path can't be parsed. Intended for paths known at compile time.
+func MustParse(s string) *Path {
+	p, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// Validate checks syntax of the human-readable path.
+func Validate(s string) error {
+	_, err := Parse(s)
+	return err
+}
+
+// Helper function to check if the character could start YANG identifier.
+func isIdentStart(b byte) bool {
+	return b == '_' || (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z')
+}
+
+// Helper function to check if the character could be a part of YANG identifier.
+func isIdentChar(b byte) bool {
+	return isIdentStart(b) || b == '-' || b == '.' || (b >= '0' && b <= '9')
+}
+
+// Helper function to identify whether the colon at the position i separates module prefix from element name or the value.
+// Values like eth[0] or uplink/spine1 after the leaf name, e.g. description, mustn't be taken for the prefixed element.
+func isPrefixColon(s string, i int, prefix string) bool {
+	if !strings.ContainsAny(prefix, "-_") {
+		return false
+	}
+	j := i + 1
+	if j >= len(s) || !isIdentStart(s[j]) {
+		return false
+	}
+	for j < len(s) && isIdentChar(s[j]) {
+		j++
+	}
+	if j >= len(s) || (s[j] != '/' && s[j] != '[') {
+		return false
+	}
+	_, err := Parse("/" + s[i+1:])
+	return err == nil
+}
+
+// Helper function to parse the key starting at position i ('[') and returns the key and position after closing ']'.
+func parseKey(s string, i int) (Key, int, error) {
+	var k Key
+	eq := strings.IndexByte(s[i:], '=')
+	if eq < 0 {
+		return k, 0, fmt.Errorf("key without value in path %q", s)
+	}
+	k.Name = s[i+1 : i+eq]
+	if k.Name == "" || strings.ContainsAny(k.Name, "[]\" ") {
+		return k, 0, fmt.Errorf("invalid key name %q in path %q", k.Name, s)
+	}
+	j := i + eq + 1
+	var v strings.Builder
+	quoted := j < len(s) && s[j] == '"'
+	if quoted {
+		j++
+	}
+	for ; j < len(s); j++ {
+		ch := s[j]
+		if ch == '\\' && j+1 < len(s) {
+			j++
+			v.WriteByte(s[j])
+			continue
+		}
+		if quoted && ch == '"' {
+			j++
+			if j >= len(s) || s[j] != ']' {
+				return k, 0, fmt.Errorf("unterminated key %q in path %q", k.Name, s)
+			}
+			k.Value = v.String()
+			return k, j + 1, nil
+		}
+		if !quoted && ch == ']' {
+			k.Value = v.String()
+			return k, j + 1, nil
+		}
+		v.WriteByte(ch)
+	}
+	return k, 0, fmt.Errorf("unterminated key %q in path %q", k.Name, s)
+}
+
+// EscapeKey renders key value, the value is quoted if contains whitespaces, brackets, quotes or backslashes.
+func EscapeKey(v string) string {
+	if v != "" && !strings.ContainsAny(v, " \t[]\"\\") {
+		return v
+	}
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	return `"` + r.Replace(v) + `"`
+}
+
+// Elem appends a new element to the path. Name could contain module prefix separated by colon.
+func (p *Path) Elem(name string) *Path {
+	e := Elem{Name: name}
+	if i := strings.IndexByte(name, ':'); i >= 0 {
+		e.Prefix, e.Name = name[:i], name[i+1:]
+	}
+	if e.Name == "" && p.err == nil {
+		p.err = fmt.Errorf("empty element name after %q", p.String())
+	}
+	p.Elems = append(p.Elems, e)
+	return p
+}
+
+// Key adds a key to the last element of the path.
+func (p *Path) Key(name, value string) *Path {
+	if len(p.Elems) == 0 {
+		if p.err == nil {
+			p.err = fmt.Errorf("key %q without element", name)
+		}
+		return p
+	}
+	if name == "" && p.err == nil {
+		p.err = fmt.Errorf("empty key name for element %q", p.Elems[len(p.Elems)-1].Name)
+	}
+	e := &p.Elems[len(p.Elems)-1]
+	e.Keys = append(e.Keys, Key{Name: name, Value: value})
+	return p
+}
+
+// Prefix sets module prefix of the last element of the path.
+func (p *Path) Prefix(prefix string) *Path {
+	if len(p.Elems) == 0 {
+		if p.err == nil {
+			p.err = fmt.Errorf("prefix %q without element", prefix)
+		}
+		return p
+	}
+	p.Elems[len(p.Elems)-1].Prefix = prefix
+	return p
+}
+
+// WithValue sets value suffix of the path.
+func (p *Path) WithValue(v string) *Path {
+	p.Value = v
+	p.HasValue = true
+	return p
+}
+
+// Err returns the first error occurred while building the path.
+func (p *Path) Err() error {
+	return p.err
+}
+
+// Copy returns a deep copy of the path, which could be extended independently.
+func (p *Path) Copy() *Path {
+	c := &Path{Value: p.Value, HasValue: p.HasValue, err: p.err}
+	for _, e := range p.Elems {
+		ce := Elem{Prefix: e.Prefix, Name: e.Name}
+		ce.Keys = append(ce.Keys, e.Keys...)
+		c.Elems = append(c.Elems, ce)
+	}
+	return c
+}
+
+// Base returns the path rendered without the value suffix.
+func (p *Path) Base() string {
+	if len(p.Elems) == 0 {
+		return "/"
+	}
+	var sb strings.Builder
+	for _, e := range p.Elems {
+		sb.WriteString("/")
+		if e.Prefix != "" {
+			sb.WriteString(e.Prefix)
+			sb.WriteString(":")
+		}
+		sb.WriteString(e.Name)
+		for _, k := range e.Keys {
+			sb.WriteString("[")
+			sb.WriteString(k.Name)
+			sb.WriteString("=")
+			sb.WriteString(EscapeKey(k.Value))
+			sb.WriteString("]")
+		}
+	}
+	return sb.String()
+}
+
+// String renders the path including the value suffix if set.
+func (p *Path) String() string {
+	if p.HasValue {
+		return p.Base() + ":" + p.Value
+	}
+	return p.Base()
+}
@@ -0,0 +1,136 @@
+//go:build unit
+
+package srljrpc_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/azyablov/srljrpc"
+	"github.com/azyablov/srljrpc/actions"
+	"github.com/azyablov/srljrpc/apierr"
+	"github.com/azyablov/srljrpc/path"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestPathParse(t *testing.T) {
+	var testData = []struct {
+		testName  string
+		path      string
+		expPath   *path.Path
+		expString string
+		expErr    bool
+	}{
+		{testName: "Root", path: "/", expPath: &path.Path{}, expString: "/"},
+		{testName: "Keys with slash",
+			path:      "/interface[name=ethernet-1/1]/description",
+			expPath:   &path.Path{Elems: []path.Elem{{Name: "interface", Keys: []path.Key{{Name: "name", Value: "ethernet-1/1"}}}, {Name: "description"}}},
+			expString: "/interface[name=ethernet-1/1]/description"},
+		{testName: "Quoted key and value with spaces",
+			path: `/network-instance[name="MAC-VRF 1"]/description:MAC-VRF 1 + REPLACED`,
+			expPath: &path.Path{Elems: []path.Elem{{Name: "network-instance", Keys: []path.Key{{Name: "name", Value: "MAC-VRF 1"}}}, {Name: "description"}},
+				Value: "MAC-VRF 1 + REPLACED", HasValue: true},
+			expString: `/network-instance[name="MAC-VRF 1"]/description:MAC-VRF 1 + REPLACED`},
+		{testName: "Escaped quote in key",
+			path:      `/a[k="x\"]y"]`,
+			expPath:   &path.Path{Elems: []path.Elem{{Name: "a", Keys: []path.Key{{Name: "k", Value: `x"]y`}}}}},
+			expString: `/a[k="x\"]y"]`},
+		{testName: "Multiple keys",
+			path:      "/a[k1=1][k2=2]/b",
+			expPath:   &path.Path{Elems: []path.Elem{{Name: "a", Keys: []path.Key{{Name: "k1", Value: "1"}, {Name: "k2", Value: "2"}}}, {Name: "b"}}},
+			expString: "/a[k1=1][k2=2]/b"},
+		{testName: "Module prefixes",
+			path:      "/srl_nokia-interfaces:interface[name=mgmt0]/srl_nokia-if:subinterface[index=0]/description",
+			expPath:   &path.Path{Elems: []path.Elem{{Prefix: "srl_nokia-interfaces", Name: "interface", Keys: []path.Key{{Name: "name", Value: "mgmt0"}}}, {Prefix: "srl_nokia-if", Name: "subinterface", Keys: []path.Key{{Name: "index", Value: "0"}}}, {Name: "description"}}},
+			expString: "/srl_nokia-interfaces:interface[name=mgmt0]/srl_nokia-if:subinterface[index=0]/description"},
+		{testName: "Value with colon",
+			path:      "/system/name/host-name:test:TEST",
+			expPath:   &path.Path{Elems: []path.Elem{{Name: "system"}, {Name: "name"}, {Name: "host-name"}}, Value: "test:TEST", HasValue: true},
+			expString: "/system/name/host-name:test:TEST"},
+		{testName: "Value with brackets",
+			path:      "/interface[name=ethernet-1/1]/description:eth[0]",
+			expPath:   &path.Path{Elems: []path.Elem{{Name: "interface", Keys: []path.Key{{Name: "name", Value: "ethernet-1/1"}}}, {Name: "description"}}, Value: "eth[0]", HasValue: true},
+			expString: "/interface[name=ethernet-1/1]/description:eth[0]"},
+		{testName: "Value with slash",
+			path:      "/interface[name=ethernet-1/1]/description:uplink/spine1",
+			expPath:   &path.Path{Elems: []path.Elem{{Name: "interface", Keys: []path.Key{{Name: "name", Value: "ethernet-1/1"}}}, {Name: "description"}}, Value: "uplink/spine1", HasValue: true},
+			expString: "/interface[name=ethernet-1/1]/description:uplink/spine1"},
+		{testName: "Path keywords", path: "/interface[name={name}]/description",
+			expPath:   &path.Path{Elems: []path.Elem{{Name: "interface", Keys: []path.Key{{Name: "name", Value: "{name}"}}}, {Name: "description"}}},
+			expString: "/interface[name={name}]/description"},
+		{testName: "Relative path", path: "system/name", expErr: true},
+		{testName: "Empty element", path: "/system//name", expErr: true},
+		{testName: "Unterminated key", path: "/interface[name=mgmt0", expErr: true},
+		{testName: "Unterminated quoted key", path: `/interface[name="MAC-VRF 1]`, expErr: true},
+		{testName: "Key without value", path: "/interface[name]", expErr: true},
+		{testName: "Characters after keys", path: "/interface[name=mgmt0]x", expErr: true},
+		{testName: "Unquoted space", path: "/network-instance[name=MAC-VRF 1]/x y", expErr: true},
+	}
+	for _, td := range testData {
+		t.Run(td.testName, func(t *testing.T) {
+			p, err := path.Parse(td.path)
+			if td.expErr {
+				if err == nil {
+					t.Fatalf("expected error, got path %v", p)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if out := cmp.Diff(td.expPath, p, cmpopts.IgnoreUnexported(path.Path{})); out != "" {
+				t.Errorf("unexpected path (-want +got):\n%s", out)
+			}
+			if p.String() != td.expString {
+				t.Errorf("expected %s, got %s", td.expString, p.String())
+			}
+		})
+	}
+}
+
+func TestPathBuilder(t *testing.T) {
+	p := path.New().Elem("srl_nokia-network-instance:network-instance").Key("name", "MAC-VRF 1").Elem("interface").Key("name", `eth\1`).Elem("description").WithValue("x")
+	if p.Err() != nil {
+		t.Fatalf("unexpected error: %v", p.Err())
+	}
+	exp := `/srl_nokia-network-instance:network-instance[name="MAC-VRF 1"]/interface[name="eth\\1"]/description:x`
+	if p.String() != exp {
+		t.Errorf("expected %s, got %s", exp, p.String())
+	}
+	if p.Base() != exp[:len(exp)-2] {
+		t.Errorf("unexpected base path %s", p.Base())
+	}
+	c := p.Copy().Prefix("srl_nokia-ni")
+	if p.Elems[2].Prefix != "" || c.Elems[2].Prefix != "srl_nokia-ni" {
+		t.Errorf("copy isn't independent: %s, %s", p, c)
+	}
+	if err := path.New().Key("name", "x").Err(); err == nil {
+		t.Errorf("expected error for key without element")
+	}
+
+	// NewCommandPath renders the built path and reports building errors
+	cmd, err := srljrpc.NewCommandPath(actions.UPDATE, path.New().Elem("interface").Key("name", "system0").Elem("description"), srljrpc.CommandValue("test"))
+	if err != nil || cmd.Path != "/interface[name=system0]/description" {
+		t.Errorf("unexpected command %v, %v", cmd, err)
+	}
+	for _, p := range []*path.Path{nil, path.New().Elem("")} {
+		if _, err := srljrpc.NewCommandPath(actions.UPDATE, p, srljrpc.CommandValue("test")); !errors.Is(err, apierr.ErrMsgCmdInvalidPath) {
+			t.Errorf("expected error %v, got %v", apierr.ErrMsgCmdInvalidPath, err)
+		}
+	}
+	// string paths are validated on demand
+	for _, p := range []string{"/interface[name=a", "interface", "/interface[name]", "/a//b"} {
+		if _, err := srljrpc.NewCommand(actions.UPDATE, p, srljrpc.CommandValue("test"), srljrpc.WithPathValidation()); !errors.Is(err, apierr.ErrMsgCmdInvalidPath) {
+			t.Errorf("%s: expected error %v, got %v", p, apierr.ErrMsgCmdInvalidPath, err)
+		}
+		if _, err := srljrpc.NewCommand(actions.UPDATE, p, srljrpc.CommandValue("test")); err != nil {
+			t.Errorf("%s: unexpected error w/o validation: %v", p, err)
+		}
+	}
+	for _, p := range []string{"", "/interface[name=ethernet-1/1]/description:uplink/spine1"} {
+		if _, err := srljrpc.NewCommand(actions.UPDATE, p, srljrpc.CommandValue(""), srljrpc.WithPathValidation()); err != nil {
+			t.Errorf("%q: unexpected error: %v", p, err)
+		}
+	}
+}
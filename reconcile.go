@@ -0,0 +1,306 @@
+package srljrpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/azyablov/srljrpc/apierr"
+	"github.com/azyablov/srljrpc/datastores"
+	"github.com/azyablov/srljrpc/formats"
+	"github.com/azyablov/srljrpc/path"
+	"github.com/azyablov/srljrpc/yms"
+	"gopkg.in/yaml.v3"
+)
+
+// ReconcilePlan type to represent changes converging RUNNING datastore to the desired state, which could be inspected before Apply.
+type ReconcilePlan struct {
+	Delete []PV
+	Update []PV
+	c      *JSONRPCClient
+}
+
+// ReconcileOption is a function type that applies options to Reconcile.
+type ReconcileOption func(*reconcileOpts)
+
+type reconcileOpts struct {
+	scopes []string
+}
+
+// ReconcileOption to set subtrees owned by the desired state: configuration under the scope paths, which is absent in the desired state, is deleted.
+// W/o scopes the plan only updates leaves of the desired state.
+func WithReconcileScope(paths ...string) ReconcileOption {
+	return func(o *reconcileOpts) {
+		o.scopes = append(o.scopes, paths...)
+	}
+}
+
+// ReadDesiredState reads desired state as YAML or JSON list of path-value pairs, e.g. [{"path": "/system/name/host-name", "value": "leaf1"}].
+func ReadDesiredState(r io.Reader) ([]PV, error) {
+	var entries []struct {
+		Path  string `yaml:"path"`
+		Value string `yaml:"value"`
+	}
+	if err := yaml.NewDecoder(r).Decode(&entries); err != nil {
+		return nil, apierr.NewClientError(apierr.CodeClntDesiredStateRead, err)
+	}
+	pvs := make([]PV, 0, len(entries))
+	for _, e := range entries {
+		pvs = append(pvs, PV{Path: e.Path, Value: CommandValue(e.Value)})
+	}
+	return pvs, nil
+}
+
+// Reconcile reads RUNNING datastore and computes the minimal set of delete and update operations converging it to the desired state,
+// where every PV sets a single leaf. The plan is validated against CANDIDATE datastore, if validation fails the plan is returned along with the error.
+func (c *JSONRPCClient) Reconcile(desired []PV, opts ...ReconcileOption) (*ReconcilePlan, error) {
+	return c.ReconcileContext(context.Background(), desired, opts...)
+}
+
+// ReconcileContext is the same as Reconcile, but uses the provided context for the requests.
+func (c *JSONRPCClient) ReconcileContext(ctx context.Context, desired []PV, opts ...ReconcileOption) (*ReconcilePlan, error) {
+	var o reconcileOpts
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	// desired state as the tree along with keys of the lists
+	to := map[string]interface{}{}
+	var dopts []DiffOption
+	var leaves [][]path.Elem
+	for _, pv := range desired {
+		p, err := path.Parse(pv.Path)
+		if err != nil {
+			return nil, apierr.NewMessageError(apierr.CodeMsgCmdInvalidPath, err)
+		}
+		v := string(pv.Value)
+		if p.HasValue {
+			v = p.Value
+		}
+		if len(p.Elems) == 0 || len(p.Elems[len(p.Elems)-1].Keys) != 0 {
+			return nil, apierr.NewClientError(apierr.CodeClntDesiredStateInvalid, fmt.Errorf("path %q is not a leaf", pv.Path))
+		}
+		if cur, ok := lookupNode(to, p.Elems); ok && cur != v {
+			return nil, apierr.NewClientError(apierr.CodeClntDesiredStateInvalid, fmt.Errorf("conflicting values for path %q", pv.Path))
+		}
+		if err := insertNode(to, p.Elems, v); err != nil {
+			return nil, apierr.NewClientError(apierr.CodeClntDesiredStateInvalid, err)
+		}
+		for _, e := range p.Elems {
+			if len(e.Keys) != 0 {
+				keys := make([]string, 0, len(e.Keys))
+				for _, k := range e.Keys {
+					keys = append(keys, k.Name)
+				}
+				dopts = append(dopts, WithDiffListKeys(e.Name, keys...))
+			}
+		}
+		leaves = append(leaves, p.Elems)
+	}
+
+	// running state limited to the scopes and the leaves of desired state
+	resp, err := c.GetContext(ctx, "/")
+	if err != nil {
+		return nil, err
+	}
+	raw, err := DecodeResult[json.RawMessage](nil, resp, 0)
+	if err != nil {
+		return nil, err
+	}
+	running, err := decodeTree(raw)
+	if err != nil {
+		return nil, err
+	}
+	running = stripModules(running).(map[string]interface{})
+	from := map[string]interface{}{}
+	for _, s := range o.scopes {
+		p, err := path.Parse(s)
+		if err != nil {
+			return nil, apierr.NewMessageError(apierr.CodeMsgCmdInvalidPath, err)
+		}
+		leaves = append(leaves, p.Elems)
+	}
+	for _, elems := range leaves {
+		if v, ok := lookupNode(running, elems); ok {
+			if err := insertNode(from, elems, v); err != nil {
+				return nil, apierr.NewClientError(apierr.CodeClntDiffTree, err)
+			}
+		}
+	}
+
+	fromJSON, err := json.Marshal(from)
+	if err != nil {
+		return nil, apierr.NewClientError(apierr.CodeClntDiffTree, err)
+	}
+	toJSON, err := json.Marshal(to)
+	if err != nil {
+		return nil, apierr.NewClientError(apierr.CodeClntDiffTree, err)
+	}
+	d, err := DiffConfig(fromJSON, toJSON, dopts...)
+	if err != nil {
+		return nil, err
+	}
+	plan := &ReconcilePlan{Delete: d.Delete, Update: d.Update, c: c}
+	// list entries w/o leaves except keys are created by setting the key leaf
+	for _, pv := range d.Replace {
+		p, err := path.Parse(pv.Path)
+		if err != nil || len(p.Elems) == 0 || len(p.Elems[len(p.Elems)-1].Keys) == 0 {
+			return nil, apierr.NewClientError(apierr.CodeClntDesiredStateInvalid, fmt.Errorf("path %q can't be reconciled", pv.Path))
+		}
+		k := p.Elems[len(p.Elems)-1].Keys[0]
+		plan.Update = append(plan.Update, PV{Path: p.Copy().Elem(k.Name).String(), Value: CommandValue(k.Value)})
+	}
+	if plan.Empty() {
+		return plan, nil
+	}
+
+	// validation of the plan
+	req, err := NewValidateRequest(plan.Delete, nil, plan.Update, yms.SRL, formats.JSON, datastores.CANDIDATE)
+	if err != nil {
+		return nil, apierr.NewClientError(apierr.CodeClntRPCReqCreation, err)
+	}
+	if _, err := c.DoContext(ctx, req); err != nil {
+		return plan, err
+	}
+	return plan, nil
+}
+
+// Empty returns true if RUNNING datastore is already in the desired state.
+func (p *ReconcilePlan) Empty() bool {
+	return len(p.Delete) == 0 && len(p.Update) == 0
+}
+
+// Apply executes the plan via BulkSet, ct is the timeout in seconds for the confirm operation, set to 0 to disable.
+// Empty plan is not applied and both response and error are nil.
+func (p *ReconcilePlan) Apply(ct int) (*Response, error) {
+	return p.ApplyContext(context.Background(), ct)
+}
+
+// ApplyContext is the same as Apply, but uses the provided context for the request.
+func (p *ReconcilePlan) ApplyContext(ctx context.Context, ct int) (*Response, error) {
+	if p.Empty() {
+		return nil, nil
+	}
+	return p.c.BulkSetContext(ctx, p.Delete, nil, p.Update, yms.SRL, ct)
+}
+
+// Helper function removing module prefixes from the names of the tree nodes.
+func stripModules(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(t))
+		for k, cv := range t {
+			if i := strings.LastIndexByte(k, ':'); i >= 0 {
+				k = k[i+1:]
+			}
+			m[k] = stripModules(cv)
+		}
+		return m
+	case []interface{}:
+		l := make([]interface{}, len(t))
+		for i, cv := range t {
+			l[i] = stripModules(cv)
+		}
+		return l
+	default:
+		return t
+	}
+}
+
+// Helper function to find list entry matching the keys of the element.
+func findEntry(l []interface{}, keys []path.Key) map[string]interface{} {
+	for _, le := range l {
+		e, ok := le.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		match := true
+		for _, k := range keys {
+			if scalarString(e[k.Name]) != k.Value {
+				match = false
+				break
+			}
+		}
+		if match {
+			return e
+		}
+	}
+	return nil
+}
+
+// Helper function returning the node of the tree under the path elements.
+func lookupNode(root map[string]interface{}, elems []path.Elem) (interface{}, bool) {
+	var node interface{} = root
+	for _, e := range elems {
+		m, ok := node.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if node, ok = m[e.Name]; !ok {
+			return nil, false
+		}
+		if len(e.Keys) == 0 {
+			continue
+		}
+		l, _ := node.([]interface{})
+		entry := findEntry(l, e.Keys)
+		if entry == nil {
+			return nil, false
+		}
+		node = entry
+	}
+	return node, true
+}
+
+// Helper function setting the node of the tree under the path elements, creating missing containers and list entries.
+// Container values are merged into existing containers and list entries.
+func insertNode(root map[string]interface{}, elems []path.Elem, v interface{}) error {
+	node := root
+	for i, e := range elems {
+		last := i == len(elems)-1
+		if len(e.Keys) == 0 && last {
+			node[e.Name] = v
+			return nil
+		}
+		if len(e.Keys) == 0 {
+			child, ok := node[e.Name].(map[string]interface{})
+			if !ok {
+				if _, exists := node[e.Name]; exists {
+					return fmt.Errorf("element %q is not a container", e.Name)
+				}
+				child = map[string]interface{}{}
+				node[e.Name] = child
+			}
+			node = child
+			continue
+		}
+		l, ok := node[e.Name].([]interface{})
+		if _, exists := node[e.Name]; exists && !ok {
+			return fmt.Errorf("element %q is not a list", e.Name)
+		}
+		entry := findEntry(l, e.Keys)
+		if entry == nil {
+			entry = map[string]interface{}{}
+			for _, k := range e.Keys {
+				entry[k.Name] = k.Value
+			}
+			node[e.Name] = append(l, entry)
+		}
+		node = entry
+	}
+	vm, ok := v.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("container value expected for path %q", pathOf(elems))
+	}
+	for k, cv := range vm {
+		node[k] = cv
+	}
+	return nil
+}
+
+// Helper function rendering path elements.
+func pathOf(elems []path.Elem) string {
+	return (&path.Path{Elems: elems}).String()
+}
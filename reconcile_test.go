@@ -0,0 +1,83 @@
+//go:build unit
+
+package srljrpc_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/azyablov/srljrpc"
+	"github.com/azyablov/srljrpc/apierr"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestReconcile(t *testing.T) {
+	s, c := helperSimClient(t)
+	desired, err := srljrpc.ReadDesiredState(strings.NewReader(`
+- path: /interface[name=ethernet-1/1]/admin-state
+  value: enable
+- path: /interface[name=ethernet-1/1]/subinterface[index=1]/description
+  value: uplink
+- path: /system/lldp/admin-state
+  value: disable
+`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	plan, err := c.Reconcile(desired, srljrpc.WithReconcileScope("/interface[name=ethernet-1/1]"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	exp := &srljrpc.ReconcilePlan{
+		Delete: []srljrpc.PV{{Path: "/interface[name=ethernet-1/1]/subinterface[index=1]/admin-state"}},
+		Update: []srljrpc.PV{
+			{Path: "/interface[name=ethernet-1/1]/subinterface[index=1]/description", Value: "uplink"},
+			{Path: "/system/lldp/admin-state", Value: "disable"}},
+	}
+	if out := cmp.Diff(exp, plan, cmpopts.IgnoreUnexported(srljrpc.ReconcilePlan{})); out != "" {
+		t.Fatalf("unexpected plan (-want +got):\n%s", out)
+	}
+
+	if _, err := plan.Apply(0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v, err := s.Get("running", "/interface[name=ethernet-1/1]/subinterface[index=1]"); err != nil ||
+		cmp.Diff(map[string]interface{}{"description": "uplink"}, v) != "" {
+		t.Errorf("unexpected subinterface after apply: %v, %v", v, err)
+	}
+
+	// Converged
+	plan, err = c.Reconcile(desired, srljrpc.WithReconcileScope("/interface[name=ethernet-1/1]"))
+	if err != nil || !plan.Empty() {
+		t.Errorf("expected empty plan, got %+v, %v", plan, err)
+	}
+	if resp, err := plan.Apply(0); resp != nil || err != nil {
+		t.Errorf("expected nothing applied, got %v, %v", resp, err)
+	}
+}
+
+func TestReconcileInvalid(t *testing.T) {
+	_, c := helperSimClient(t)
+	var testData = []struct {
+		testName string
+		desired  []srljrpc.PV
+		expErr   error
+	}{
+		{"Not a leaf", []srljrpc.PV{{Path: "/interface[name=ethernet-1/1]", Value: "x"}}, apierr.ErrClntDesiredStateInvalid},
+		{"Conflicting values", []srljrpc.PV{{Path: "/system/name/host-name", Value: "a"}, {Path: "/system/name/host-name", Value: "b"}}, apierr.ErrClntDesiredStateInvalid},
+		{"Invalid path", []srljrpc.PV{{Path: "/interface[name=a/description", Value: "x"}}, apierr.ErrMsgCmdInvalidPath},
+	}
+	for _, td := range testData {
+		t.Run(td.testName, func(t *testing.T) {
+			if _, err := c.Reconcile(td.desired); !errors.Is(err, td.expErr) {
+				t.Errorf("expected error %v, got %v", td.expErr, err)
+			}
+		})
+	}
+	if _, err := srljrpc.ReadDesiredState(strings.NewReader(`path: [`)); !errors.Is(err, apierr.ErrClntDesiredStateRead) {
+		t.Errorf("expected error %v, got %v", apierr.ErrClntDesiredStateRead, err)
+	}
+}
@@ -0,0 +1,420 @@
+package srljrpc
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/azyablov/srljrpc/apierr"
+	"github.com/azyablov/srljrpc/path"
+)
+
+// Redacted is the value used instead of credentials and secrets in recorded exchanges.
+const Redacted = "REDACTED"
+
+// Exchange type to represent a single HTTP exchange with JSON RPC server captured by WithOptRecorder, one per line of JSONL file.
+// Authorization headers and values of secret-looking leaves (password, secret, token) are redacted.
+type Exchange struct {
+	Time     time.Time       `json:"time"`
+	URL      string          `json:"url"`
+	Header   http.Header     `json:"header,omitempty"`
+	Request  json.RawMessage `json:"request"`
+	Status   int             `json:"status,omitempty"`
+	Response json.RawMessage `json:"response,omitempty"`
+	Error    string          `json:"error,omitempty"`
+}
+
+// recorder type to represent http.RoundTripper appending exchanges to JSONL file.
+type recorder struct {
+	next http.RoundTripper
+	path string
+	mu   sync.Mutex
+}
+
+// ClientOption to record every exchange with the target into JSONL file, exchanges are appended if the file exists.
+// Failures to write the record after the file is opened don't affect the requests.
+func WithOptRecorder(path string) ClientOption {
+	return func(c *JSONRPCClient) error {
+		f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+		if err != nil {
+			return apierr.NewClientError(apierr.CodeClntRecorder, err)
+		}
+		f.Close()
+		c.recorder = &recorder{path: path}
+		return nil
+	}
+}
+
+// RoundTrip executes HTTP request using the next RoundTripper and records the exchange.
+func (r *recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	e := Exchange{Time: time.Now(), URL: req.URL.String(), Header: redactHeader(req.Header)}
+	var reqBody []byte
+	if req.GetBody != nil {
+		if rc, err := req.GetBody(); err == nil {
+			reqBody, _ = io.ReadAll(rc)
+			rc.Close()
+		}
+	}
+	e.Request = redactJSON(reqBody)
+
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		e.Error = err.Error()
+		r.write(e)
+		return nil, err
+	}
+	b, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		e.Error = err.Error()
+		r.write(e)
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(b))
+	e.Status = resp.StatusCode
+	e.Response = redactResponse(reqBody, b)
+	r.write(e)
+	return resp, nil
+}
+
+// Helper method to append the exchange to the file.
+func (r *recorder) write(e Exchange) {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	f, err := os.OpenFile(r.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	f.Write(append(b, '\n'))
+}
+
+// ReplayTransport type to represent http.RoundTripper answering requests with exchanges recorded by WithOptRecorder.
+// Requests are matched on method, params and datastore (ignoring request ID), while response IDs are rewritten to the request ones.
+// Exchanges matching the same request are replayed in recorded order and the last one is repeated afterwards.
+type ReplayTransport struct {
+	mu        sync.Mutex
+	exchanges map[string][]Exchange
+}
+
+// ReadReplay reads exchanges recorded by WithOptRecorder from r and returns ReplayTransport.
+func ReadReplay(r io.Reader) (*ReplayTransport, error) {
+	rt := &ReplayTransport{exchanges: map[string][]Exchange{}}
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for n := 1; sc.Scan(); n++ {
+		if len(bytes.TrimSpace(sc.Bytes())) == 0 {
+			continue
+		}
+		var e Exchange
+		if err := json.Unmarshal(sc.Bytes(), &e); err != nil {
+			return nil, apierr.NewClientError(apierr.CodeClntReplay, fmt.Errorf("line %d: %w", n, err))
+		}
+		if e.Error != "" {
+			continue
+		}
+		k, _, err := replayKey(e.Request)
+		if err != nil {
+			return nil, apierr.NewClientError(apierr.CodeClntReplay, fmt.Errorf("line %d: %w", n, err))
+		}
+		rt.exchanges[k] = append(rt.exchanges[k], e)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, apierr.NewClientError(apierr.CodeClntReplay, err)
+	}
+	return rt, nil
+}
+
+// ClientOption to replay exchanges recorded by WithOptRecorder from the file instead of sending requests to the target.
+// Replaces HTTP transport, so can't be combined with WithOptTransport().
+func WithOptReplay(path string) ClientOption {
+	return func(c *JSONRPCClient) error {
+		if c.transport != nil {
+			return apierr.NewClientError(apierr.CodeClntTransportConflict, nil)
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return apierr.NewClientError(apierr.CodeClntReplay, err)
+		}
+		defer f.Close()
+		rt, err := ReadReplay(f)
+		if err != nil {
+			return err
+		}
+		c.transport = rt
+		return nil
+	}
+}
+
+// RoundTrip answers HTTP request with the matching recorded exchange.
+func (rt *ReplayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		b, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		body = b
+	}
+	k, ids, err := replayKey(redactJSON(body))
+	if err != nil {
+		return nil, apierr.NewClientError(apierr.CodeClntReplayNoMatch, err)
+	}
+
+	rt.mu.Lock()
+	es := rt.exchanges[k]
+	if len(es) == 0 {
+		rt.mu.Unlock()
+		return nil, apierr.NewClientError(apierr.CodeClntReplayNoMatch, nil)
+	}
+	e := es[0]
+	if len(es) > 1 {
+		rt.exchanges[k] = es[1:]
+	}
+	rt.mu.Unlock()
+
+	_, recIDs, _ := replayKey(e.Request)
+	respBody, err := replayIDs(e.Response, recIDs, ids)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", e.Status, http.StatusText(e.Status)),
+		StatusCode:    e.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": {"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(respBody)),
+		ContentLength: int64(len(respBody)),
+		Request:       req,
+	}, nil
+}
+
+// Helper function to build the matching key of the request (single or batch) w/o IDs, IDs are returned in order of appearance.
+func replayKey(b []byte) (string, []json.RawMessage, error) {
+	var v interface{}
+	if err := unmarshalNumber(b, &v); err != nil {
+		return "", nil, err
+	}
+	var ids []json.RawMessage
+	forEachObject(v, func(o map[string]interface{}) {
+		id, _ := json.Marshal(o["id"])
+		ids = append(ids, id)
+		delete(o, "id")
+	})
+	k, err := json.Marshal(v)
+	if err != nil {
+		return "", nil, err
+	}
+	return string(k), ids, nil
+}
+
+// Helper function to rewrite response IDs from recorded request IDs to the actual ones.
+func replayIDs(b json.RawMessage, from, to []json.RawMessage) ([]byte, error) {
+	if len(b) == 0 {
+		return b, nil
+	}
+	m := map[string]json.RawMessage{}
+	for i := range from {
+		if i < len(to) {
+			m[string(from[i])] = to[i]
+		}
+	}
+	var v interface{}
+	if err := unmarshalNumber(b, &v); err != nil {
+		return nil, err
+	}
+	forEachObject(v, func(o map[string]interface{}) {
+		id, _ := json.Marshal(o["id"])
+		if n, ok := m[string(id)]; ok {
+			o["id"] = n
+		}
+	})
+	return json.Marshal(v)
+}
+
+// Helper function to call f for the JSON RPC object or for every object of the batch.
+func forEachObject(v interface{}, f func(o map[string]interface{})) {
+	if l, ok := v.([]interface{}); ok {
+		for _, e := range l {
+			forEachObject(e, f)
+		}
+		return
+	}
+	if o, ok := v.(map[string]interface{}); ok {
+		f(o)
+	}
+}
+
+// Helper function to redact credentials in HTTP headers.
+func redactHeader(h http.Header) http.Header {
+	r := h.Clone()
+	for _, k := range []string{"Authorization", "Proxy-Authorization", "Cookie"} {
+		if r.Get(k) != "" {
+			r.Set(k, Redacted)
+		}
+	}
+	return r
+}
+
+// Helper function to redact values of secret-looking leaves in JSON document, invalid JSON is dropped.
+func redactJSON(b []byte) json.RawMessage {
+	var v interface{}
+	if err := unmarshalNumber(b, &v); err != nil {
+		return nil
+	}
+	r, err := json.Marshal(redactValue(v))
+	if err != nil {
+		return nil
+	}
+	return r
+}
+
+// Helper function to redact response like redactJSON, results of commands with secret-looking leaf path are redacted as well.
+func redactResponse(req, resp []byte) json.RawMessage {
+	var q, v interface{}
+	if err := unmarshalNumber(resp, &v); err != nil {
+		return nil
+	}
+	if err := unmarshalNumber(req, &q); err == nil {
+		reqs := map[string]map[string]interface{}{}
+		forEachObject(q, func(o map[string]interface{}) {
+			id, _ := json.Marshal(o["id"])
+			reqs[string(id)] = o
+		})
+		// results follow the order of commands
+		forEachObject(v, func(o map[string]interface{}) {
+			id, _ := json.Marshal(o["id"])
+			params, _ := reqs[string(id)]["params"].(map[string]interface{})
+			cmds, _ := params["commands"].([]interface{})
+			res, _ := o["result"].([]interface{})
+			for i, cmd := range cmds {
+				if cm, ok := cmd.(map[string]interface{}); ok && i < len(res) {
+					if p, ok := cm["path"].(string); ok && isSecretPath(p) {
+						res[i] = Redacted
+					}
+				}
+			}
+		})
+	}
+	r, err := json.Marshal(redactValue(v))
+	if err != nil {
+		return nil
+	}
+	return r
+}
+
+// Helper function to redact values of secret-looking leaves, commands are redacted by the leaf name of the path.
+func redactValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		if p, ok := t["path"].(string); ok {
+			if rp, secret := redactPath(p); secret {
+				t["path"] = rp
+				if _, ok := t["value"]; ok {
+					t["value"] = Redacted
+				}
+			}
+		}
+		for k, e := range t {
+			if isSecret(k) {
+				t[k] = Redacted
+				continue
+			}
+			t[k] = redactValue(e)
+		}
+	case []interface{}:
+		for i, e := range t {
+			t[i] = redactValue(e)
+		}
+	}
+	return v
+}
+
+// Helper function to check whether the leaf name looks like a secret.
+func isSecret(name string) bool {
+	name = strings.ToLower(name)
+	if i := strings.IndexByte(name, ':'); i >= 0 {
+		name = name[i+1:]
+	}
+	for _, s := range []string{"password", "secret", "token"} {
+		if strings.Contains(name, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// Helper function to check whether the path points to secret-looking leaf, the value suffix of the path isn't taken into account.
+func isSecretPath(p string) bool {
+	_, secret := redactPath(p)
+	return secret
+}
+
+// Helper function to redact the value suffix of the path pointing to secret-looking leaf,
+// e.g. /system/aaa/authentication/user[username=admin]/password:hunter2, returns the path as is otherwise.
+func redactPath(s string) (string, bool) {
+	p, err := path.Parse(s)
+	if err != nil || len(p.Elems) == 0 {
+		// unparsable path: name of the leaf as well as its value suffix could be a secret name
+		leaf := leafName(s)
+		for _, part := range strings.Split(leaf, ":") {
+			if isSecret(part) {
+				if i := strings.IndexByte(leaf, ':'); i >= 0 {
+					return s[:strings.LastIndex(s, leaf)+i+1] + Redacted, true
+				}
+				return s, true
+			}
+		}
+		return s, false
+	}
+	if !isSecret(p.Elems[len(p.Elems)-1].Name) {
+		return s, false
+	}
+	if p.HasValue {
+		s = s[:len(s)-len(p.Value)] + Redacted
+	}
+	return s, true
+}
+
+// Helper function to return the name of the last element of the path w/o keys.
+func leafName(p string) string {
+	depth, start := 0, 0
+	for i, r := range p {
+		switch r {
+		case '[':
+			depth++
+		case ']':
+			depth--
+		case '/':
+			if depth == 0 {
+				start = i + 1
+			}
+		}
+	}
+	p = p[start:]
+	if i := strings.IndexByte(p, '['); i >= 0 {
+		p = p[:i]
+	}
+	return p
+}
+
+// Helper function to unmarshal JSON preserving numbers as is.
+func unmarshalNumber(b []byte, v interface{}) error {
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	return d.Decode(v)
+}
@@ -0,0 +1,144 @@
+//go:build unit
+
+package srljrpc_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/azyablov/srljrpc"
+	"github.com/azyablov/srljrpc/apierr"
+	"github.com/azyablov/srljrpc/datastores"
+	"github.com/azyablov/srljrpc/formats"
+	"github.com/azyablov/srljrpc/srljrpctest"
+)
+
+func TestRecordReplay(t *testing.T) {
+	s := srljrpctest.NewServer()
+	t.Cleanup(s.Close)
+	file := filepath.Join(t.TempDir(), "exchanges.jsonl")
+	pwPath := "/system/aaa/authentication/user[username=netops]/password"
+
+	// Recording
+	c, err := s.NewClient(srljrpc.WithOptRecorder(file))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := c.Update(0, srljrpc.PV{Path: pwPath, Value: "s3cr3t"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// secret provided as the value suffix of the path
+	if _, err := c.Update(0, srljrpc.PV{Path: pwPath + ":hunter2"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := c.Get(pwPath); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	recGet, err := c.Get("/system/name")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b := helperBatch(t)
+	if _, err := c.DoBatch(b); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(raw), "s3cr3t") || strings.Contains(string(raw), "hunter2") || strings.Contains(string(raw), s.Password) {
+		t.Errorf("credentials aren't redacted:\n%s", raw)
+	}
+	if !strings.Contains(string(raw), pwPath+":"+srljrpc.Redacted) {
+		t.Errorf("expected value suffix of the path redacted:\n%s", raw)
+	}
+	var n int
+	sc := bufio.NewScanner(strings.NewReader(string(raw)))
+	for sc.Scan() {
+		var e srljrpc.Exchange
+		if err := json.Unmarshal(sc.Bytes(), &e); err != nil {
+			t.Fatalf("can't unmarshal exchange: %v", err)
+		}
+		if e.Header.Get("Authorization") != srljrpc.Redacted {
+			t.Errorf("expected redacted Authorization header, got %q", e.Header.Get("Authorization"))
+		}
+		n++
+	}
+	// target verification, 2 updates, 2 gets and batch
+	if n != 6 {
+		t.Errorf("expected 6 exchanges, got %d", n)
+	}
+
+	// Replaying w/o the server
+	s.Close()
+	host := "offline"
+	c, err = srljrpc.NewJSONRPCClient(&host, srljrpc.WithOptReplay(file))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.GetHostname() != "srl" {
+		t.Errorf("expected hostname srl, got %s", c.GetHostname())
+	}
+	getResp, err := c.Get("/system/name")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(getResp.Result) != string(recGet.Result) {
+		t.Errorf("expected result %s, got %s", recGet.Result, getResp.Result)
+	}
+	res, err := c.DoBatch(helperBatch(t))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i, r := range res {
+		if r.Err != nil || r.Response == nil {
+			t.Errorf("unexpected result of request %d: %v", i, r.Err)
+		}
+	}
+	if _, err := c.Get("/system/lldp"); !errors.Is(err, apierr.ErrClntReplayNoMatch) {
+		t.Errorf("expected error %v, got %v", apierr.ErrClntReplayNoMatch, err)
+	}
+
+	if _, err := srljrpc.NewJSONRPCClient(&host, srljrpc.WithOptReplay(filepath.Join(t.TempDir(), "none"))); !errors.Is(err, apierr.ErrClntReplay) {
+		t.Errorf("expected error %v, got %v", apierr.ErrClntReplay, err)
+	}
+	for _, opts := range [][]srljrpc.ClientOption{
+		{srljrpc.WithOptReplay(file), srljrpc.WithOptTransport(http.DefaultTransport)},
+		{srljrpc.WithOptTransport(http.DefaultTransport), srljrpc.WithOptReplay(file)},
+	} {
+		if _, err := srljrpc.NewJSONRPCClient(&host, opts...); !errors.Is(err, apierr.ErrClntTransportConflict) {
+			t.Errorf("expected error %v, got %v", apierr.ErrClntTransportConflict, err)
+		}
+	}
+	if _, err := srljrpc.ReadReplay(strings.NewReader("{")); !errors.Is(err, apierr.ErrClntReplay) {
+		t.Errorf("expected error %v, got %v", apierr.ErrClntReplay, err)
+	}
+	if _, err := s.NewClient(srljrpc.WithOptRecorder(t.TempDir())); !errors.Is(err, apierr.ErrClntRecorder) {
+		t.Errorf("expected error %v, got %v", apierr.ErrClntRecorder, err)
+	}
+}
+
+// Helper function to build a batch of two GET requests.
+func helperBatch(t *testing.T) *srljrpc.Batch {
+	t.Helper()
+	var rs []srljrpc.Requester
+	for _, p := range []string{"/system/name/host-name", "/system/lldp/admin-state"} {
+		r, err := srljrpc.NewGetRequest([]string{p}, false, false, formats.JSON, datastores.RUNNING)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		rs = append(rs, r)
+	}
+	b, err := srljrpc.NewBatch(rs...)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return b
+}
@@ -0,0 +1,102 @@
+package srljrpc
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/azyablov/srljrpc/apierr"
+)
+
+// ResultError type to represent failure to decode result of the particular command.
+//
+//	Index is the index of the command in the request and the result in the response.
+//	Path is the path of the command (or CLI command), which originated the result; empty if unknown.
+//	Err is ClientError with code CodeClntResultIndex or CodeClntResultDecoding wrapping the original error.
+type ResultError struct {
+	Index int
+	Path  string
+	Err   error
+}
+
+func (e *ResultError) Error() string {
+	msg := e.Err.Error()
+	if ce, ok := e.Err.(apierr.ClientError); ok && ce.Err != nil {
+		msg = fmt.Sprintf("%s: %v", msg, ce.Err)
+	}
+	if e.Path == "" {
+		return fmt.Sprintf("result %d: %s", e.Index, msg)
+	}
+	return fmt.Sprintf("result %d for %q: %s", e.Index, e.Path, msg)
+}
+
+func (e *ResultError) Unwrap() error {
+	return e.Err
+}
+
+// Results returns results of the response per command in order of commands in the request.
+func (r *Response) Results() ([]json.RawMessage, error) {
+	var results []json.RawMessage
+	if err := json.Unmarshal(r.Result, &results); err != nil {
+		return nil, apierr.NewClientError(apierr.CodeClntRespJSONUnmarshalling, err)
+	}
+	return results, nil
+}
+
+// ResultAt decodes result of the command with index n into v.
+func (r *Response) ResultAt(n int, v interface{}) error {
+	return decodeResultAt(nil, r, n, v)
+}
+
+// DecodeResult decodes result of the command with index n of the request into a value of type T.
+// Returned error is *ResultError including originating command path if result is missing or its shape doesn't match T.
+func DecodeResult[T any](req Requester, resp *Response, n int) (T, error) {
+	var v T
+	err := decodeResultAt(req, resp, n, &v)
+	return v, err
+}
+
+// EachResult iterates over results of the response calling fn with command index, originating command path and raw result.
+// Iteration is stopped on the first error returned by fn.
+func EachResult(req Requester, resp *Response, fn func(n int, path string, result json.RawMessage) error) error {
+	results, err := resp.Results()
+	if err != nil {
+		return err
+	}
+	for n, res := range results {
+		if err := fn(n, resultPath(req, n), res); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Helper function decoding result with index n into v and wrapping errors into ResultError.
+func decodeResultAt(req Requester, resp *Response, n int, v interface{}) error {
+	results, err := resp.Results()
+	if err != nil {
+		return err
+	}
+	if n < 0 || n >= len(results) {
+		return &ResultError{Index: n, Path: resultPath(req, n),
+			Err: apierr.NewClientError(apierr.CodeClntResultIndex, fmt.Errorf("response has %d results", len(results)))}
+	}
+	if err := json.Unmarshal(results[n], v); err != nil {
+		return &ResultError{Index: n, Path: resultPath(req, n), Err: apierr.NewClientError(apierr.CodeClntResultDecoding, err)}
+	}
+	return nil
+}
+
+// Helper function returning path (or CLI command) of the command with index n of the request, empty if unknown.
+func resultPath(req Requester, n int) string {
+	switch r := req.(type) {
+	case *Request:
+		if r != nil && r.Params != nil && n >= 0 && n < len(r.Params.Commands) {
+			return r.Params.Commands[n].Path
+		}
+	case *CLIRequest:
+		if r != nil && r.Params != nil && n >= 0 && n < len(r.Params.Commands) {
+			return r.Params.Commands[n]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,91 @@
+//go:build unit
+
+package srljrpc_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/azyablov/srljrpc"
+	"github.com/azyablov/srljrpc/apierr"
+	"github.com/azyablov/srljrpc/datastores"
+	"github.com/azyablov/srljrpc/formats"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDecodeResult(t *testing.T) {
+	_, c := helperSimClient(t)
+	type jsonRPCServer struct {
+		AdminState string `json:"admin-state"`
+	}
+
+	paths := []string{"/system/name/host-name", "/system/json-rpc-server"}
+	req, err := srljrpc.NewGetRequest(paths, true, false, formats.JSON, datastores.RUNNING)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp, err := c.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Typed decoding
+	hostname, err := srljrpc.DecodeResult[string](req, resp, 0)
+	if err != nil || hostname != c.GetHostname() {
+		t.Errorf("expected hostname %s, got %s, %v", c.GetHostname(), hostname, err)
+	}
+	srv, err := srljrpc.DecodeResult[jsonRPCServer](req, resp, 1)
+	if err != nil || srv.AdminState != "enable" {
+		t.Errorf("unexpected json-rpc-server: %+v, %v", srv, err)
+	}
+	var name string
+	if err := resp.ResultAt(0, &name); err != nil || name != hostname {
+		t.Errorf("expected hostname %s, got %s, %v", hostname, name, err)
+	}
+
+	// Shape mismatch and index out of range
+	var testData = []struct {
+		testName string
+		decode   func() error
+		expErr   error
+		expIndex int
+		expPath  string
+	}{
+		{testName: "Shape mismatch", decode: func() error { _, err := srljrpc.DecodeResult[int](req, resp, 1); return err },
+			expErr: apierr.ErrClntResultDecoding, expIndex: 1, expPath: "/system/json-rpc-server"},
+		{testName: "Index out of range", decode: func() error { _, err := srljrpc.DecodeResult[string](req, resp, 2); return err },
+			expErr: apierr.ErrClntResultIndex, expIndex: 2},
+		{testName: "Shape mismatch without request", decode: func() error { var v []string; return resp.ResultAt(0, &v) },
+			expErr: apierr.ErrClntResultDecoding, expIndex: 0},
+	}
+	for _, td := range testData {
+		t.Run(td.testName, func(t *testing.T) {
+			err := td.decode()
+			if !errors.Is(err, td.expErr) {
+				t.Fatalf("expected error %v, got %v", td.expErr, err)
+			}
+			var re *srljrpc.ResultError
+			if !errors.As(err, &re) || re.Index != td.expIndex || re.Path != td.expPath {
+				t.Fatalf("unexpected ResultError: %+v", re)
+			}
+			if td.expPath != "" && !strings.Contains(err.Error(), td.expPath) {
+				t.Errorf("expected path %s in error message, got %s", td.expPath, err.Error())
+			}
+		})
+	}
+
+	// Iterating over results
+	var gotPaths []string
+	err = srljrpc.EachResult(req, resp, func(n int, path string, result json.RawMessage) error {
+		gotPaths = append(gotPaths, path)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out := cmp.Diff(paths, gotPaths); out != "" {
+		t.Errorf("unexpected paths (-want +got):\n%s", out)
+	}
+}
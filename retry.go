@@ -0,0 +1,145 @@
+package srljrpc
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"time"
+
+	"github.com/azyablov/srljrpc/apierr"
+	"github.com/azyablov/srljrpc/methods"
+)
+
+// Default values of the retry policy.
+const (
+	defRetryMaxAttempts = 3
+	defRetryBaseDelay   = 200 * time.Millisecond
+	defRetryMaxDelay    = 5 * time.Second
+	defRetryJitter      = 0.2
+)
+
+// RetryPolicy type to represent retry policy applied by JSONRPCClient to transient failures.
+//
+//	MaxAttempts is the total number of attempts including the first one.
+//	BaseDelay is the delay before the second attempt, which is doubled for every next attempt.
+//	MaxDelay is the upper bound of the delay, zero means no upper bound.
+//	Jitter is the fraction [0, 1] of the delay randomized to avoid synchronized retries.
+//	RetrySet enables retries of SET requests, which are not idempotent in general (e.g. confirm timeout or tools actions).
+//	RetryCLI enables retries of CLI requests, which could change configuration.
+//	Retryable classifies errors, IsRetryable() is used if nil.
+//
+// GET, VALIDATE and DIFF requests are retried by default.
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+	Jitter      float64
+	RetrySet    bool
+	RetryCLI    bool
+	Retryable   func(err error) bool
+}
+
+// DefaultRetryPolicy returns retry policy with 3 attempts and exponential backoff starting from 200ms, limited by 5s.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: defRetryMaxAttempts,
+		BaseDelay:   defRetryBaseDelay,
+		MaxDelay:    defRetryMaxDelay,
+		Jitter:      defRetryJitter,
+	}
+}
+
+// ClientOption to set retry policy for requests sent by the client.
+func WithOptRetry(p RetryPolicy) ClientOption {
+	return func(c *JSONRPCClient) error {
+		if p.MaxAttempts < 1 || p.BaseDelay < 0 || p.MaxDelay < 0 || p.Jitter < 0 || p.Jitter > 1 {
+			return apierr.NewClientError(apierr.CodeClntRetryPolicy, nil)
+		}
+		c.retry = &p
+		return nil
+	}
+}
+
+// IsRetryable reports whether the error returned by JSONRPCClient is transient:
+// failure to send HTTP request including transport timeouts and HTTP status 429, 502, 503 or 504.
+// JSON RPC errors returned by the target, malformed responses and context cancellation are never retried.
+func IsRetryable(err error) bool {
+	switch {
+	case err == nil, errors.Is(err, apierr.ErrClntCtxDone), errors.Is(err, apierr.ErrClntJSONRPCResp):
+		return false
+	case errors.Is(err, apierr.ErrClntHTTPSend):
+		return true
+	case errors.Is(err, apierr.ErrClntHTTPStatus):
+		var se *HTTPStatusError
+		if !errors.As(err, &se) {
+			return false
+		}
+		switch se.StatusCode {
+		case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+	}
+	return false
+}
+
+// Helper method checking whether requests of the method are allowed to be retried.
+func (p *RetryPolicy) allowed(m methods.EnumMethods) bool {
+	switch m {
+	case methods.GET, methods.VALIDATE, methods.DIFF:
+		return true
+	case methods.SET:
+		return p.RetrySet
+	case methods.CLI:
+		return p.RetryCLI
+	}
+	return false
+}
+
+// Helper method calculating delay before the attempt n (starting from 1 for the first retry).
+func (p *RetryPolicy) delay(n int) time.Duration {
+	d := p.BaseDelay
+	for i := 1; i < n && (p.MaxDelay == 0 || d < p.MaxDelay); i++ {
+		d *= 2
+	}
+	if p.MaxDelay != 0 && d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	if p.Jitter > 0 && d > 0 {
+		// randomizing delay within [d*(1-jitter), d*(1+jitter)]
+		d = time.Duration(float64(d) * (1 + p.Jitter*(2*rand.Float64()-1)))
+	}
+	return d
+}
+
+// Executes the attempt function retrying transient failures in accordance with retry policy, facilitates DoContext and DoBatchContext.
+// Retries are applied only if methods of all requests are allowed to be retried.
+func (c *JSONRPCClient) withRetry(ctx context.Context, rs []Requester, attempt func() error) error {
+	p := c.retry
+	if p == nil {
+		return attempt()
+	}
+	for _, r := range rs {
+		m, err := r.GetMethod()
+		if err != nil || !p.allowed(m) {
+			return attempt()
+		}
+	}
+	retryable := p.Retryable
+	if retryable == nil {
+		retryable = IsRetryable
+	}
+	for n := 1; ; n++ {
+		err := attempt()
+		if err == nil || n >= p.MaxAttempts || !retryable(err) {
+			return err
+		}
+		t := time.NewTimer(p.delay(n))
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return apierr.NewClientError(apierr.CodeClntCtxDone, ctx.Err())
+		case <-t.C:
+		}
+	}
+}
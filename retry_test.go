@@ -0,0 +1,114 @@
+//go:build unit
+
+package srljrpc_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/azyablov/srljrpc"
+	"github.com/azyablov/srljrpc/apierr"
+	"github.com/azyablov/srljrpc/srljrpctest"
+)
+
+func TestRetry(t *testing.T) {
+	s := srljrpctest.NewServer()
+	defer s.Close()
+	p := srljrpc.DefaultRetryPolicy()
+	p.BaseDelay = time.Millisecond
+	c, err := s.NewClient(srljrpc.WithOptRetry(p))
+	if err != nil {
+		t.Fatalf("can't create client: %v", err)
+	}
+	pSet := p
+	pSet.RetrySet = true
+	cSet, err := s.NewClient(srljrpc.WithOptRetry(pSet))
+	if err != nil {
+		t.Fatalf("can't create client: %v", err)
+	}
+	upd := []srljrpc.PV{{Path: "/interface[name=system0]/description", Value: srljrpc.CommandValue("RETRY")}}
+
+	var testData = []struct {
+		testName    string
+		faults      int
+		status      int
+		call        func() error
+		expErr      error
+		expStatus   int
+		expAttempts int
+	}{
+		{testName: "GET retried on 503", faults: 2, status: http.StatusServiceUnavailable,
+			call:        func() error { _, err := c.Get("/system/name"); return err },
+			expAttempts: 3},
+		{testName: "GET retried on connection reset", faults: 1, status: 0,
+			call:        func() error { _, err := c.Get("/system/name"); return err },
+			expAttempts: 2},
+		{testName: "GET attempts exhausted", faults: 3, status: http.StatusBadGateway,
+			call:   func() error { _, err := c.Get("/system/name"); return err },
+			expErr: apierr.ErrClntHTTPStatus, expStatus: http.StatusBadGateway, expAttempts: 3},
+		{testName: "GET not retried on 500", faults: 1, status: http.StatusInternalServerError,
+			call:   func() error { _, err := c.Get("/system/name"); return err },
+			expErr: apierr.ErrClntHTTPStatus, expStatus: http.StatusInternalServerError, expAttempts: 1},
+		{testName: "JSON RPC error not retried",
+			call:        func() error { _, err := c.Get("/system/invalid"); return err },
+			expErr:      apierr.ErrClntJSONRPCResp,
+			expAttempts: 1},
+		{testName: "SET not retried by default", faults: 1, status: http.StatusServiceUnavailable,
+			call:   func() error { _, err := c.Update(0, upd...); return err },
+			expErr: apierr.ErrClntHTTPStatus, expStatus: http.StatusServiceUnavailable, expAttempts: 1},
+		{testName: "SET retried with opt-in", faults: 1, status: http.StatusServiceUnavailable,
+			call:        func() error { _, err := cSet.Update(0, upd...); return err },
+			expAttempts: 2},
+	}
+	for _, td := range testData {
+		t.Run(td.testName, func(t *testing.T) {
+			s.FailNext(td.faults, td.status)
+			before := s.Requests()
+			err := td.call()
+			if td.expErr == nil && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if td.expErr != nil && !errors.Is(err, td.expErr) {
+				t.Fatalf("expected error %v, got %v", td.expErr, err)
+			}
+			if td.expStatus != 0 {
+				var se *srljrpc.HTTPStatusError
+				if !errors.As(err, &se) || se.StatusCode != td.expStatus {
+					t.Errorf("expected HTTP status %d, got %v", td.expStatus, se)
+				}
+			}
+			if n := s.Requests() - before; n != td.expAttempts {
+				t.Errorf("expected %d attempts, got %d", td.expAttempts, n)
+			}
+		})
+	}
+
+	// Context canceled while waiting for the next attempt
+	pLong := p
+	pLong.BaseDelay = time.Hour
+	cLong, err := s.NewClient(srljrpc.WithOptRetry(pLong))
+	if err != nil {
+		t.Fatalf("can't create client: %v", err)
+	}
+	s.FailNext(1, http.StatusServiceUnavailable)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := cLong.GetContext(ctx, "/system/name"); !errors.Is(err, apierr.ErrClntCtxDone) {
+		t.Errorf("expected error %v, got %v", apierr.ErrClntCtxDone, err)
+	}
+
+	// Malformed responses aren't fixed by retries
+	for _, code := range []apierr.EnumCltErr{apierr.CodeClntRespJSONUnmarshalling, apierr.CodeClntIDMismatch, apierr.CodeClntResultDecoding} {
+		if srljrpc.IsRetryable(apierr.NewClientError(code, nil)) {
+			t.Errorf("expected %v not retryable", code)
+		}
+	}
+
+	// Policy validation
+	if _, err := s.NewClient(srljrpc.WithOptRetry(srljrpc.RetryPolicy{})); !errors.Is(err, apierr.ErrClntRetryPolicy) {
+		t.Errorf("expected error %v, got %v", apierr.ErrClntRetryPolicy, err)
+	}
+}
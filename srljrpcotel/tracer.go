@@ -0,0 +1,75 @@
+// Package srljrpcotel provides OpenTelemetry implementation of srljrpc.Tracer on top of a caller-supplied tracer provider.
+//
+// Spans created (children of the span found in the request context):
+//
+//	srljrpc.Do - client span of every request with target host, method, datastore, yang-models, command count and request ID attributes
+//	srljrpc.BulkSetCallBack[.set|.wait|.callback|.confirm-accept] - steps of BulkSetCallBack
+package srljrpcotel
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/azyablov/srljrpc"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Name of the tracer used to create spans.
+const tracerName = "github.com/azyablov/srljrpc"
+
+// Tracer type to represent OpenTelemetry tracer implementing srljrpc.Tracer.
+type Tracer struct {
+	tracer trace.Tracer
+}
+
+// New creates Tracer creating spans via the tracer provider, e.g. otel.GetTracerProvider().
+func New(tp trace.TracerProvider) *Tracer {
+	return &Tracer{tracer: tp.Tracer(tracerName)}
+}
+
+// StartSpan starts the span, implements srljrpc.Tracer.
+func (t *Tracer) StartSpan(ctx context.Context, s srljrpc.SpanStart) (context.Context, srljrpc.Span) {
+	opts := []trace.SpanStartOption{trace.WithAttributes(attributes(s.Attributes)...)}
+	if s.Client {
+		opts = append(opts, trace.WithSpanKind(trace.SpanKindClient))
+	}
+	ctx, span := t.tracer.Start(ctx, s.Name, opts...)
+	return ctx, otelSpan{span}
+}
+
+// otelSpan type to represent OpenTelemetry span implementing srljrpc.Span.
+type otelSpan struct {
+	span trace.Span
+}
+
+func (s otelSpan) SetAttributes(attrs ...srljrpc.SpanAttribute) {
+	s.span.SetAttributes(attributes(attrs)...)
+}
+
+func (s otelSpan) End(err error) {
+	if err != nil {
+		s.span.RecordError(err)
+		s.span.SetStatus(codes.Error, err.Error())
+	}
+	s.span.End()
+}
+
+// Helper function converting span attributes into OpenTelemetry ones.
+func attributes(attrs []srljrpc.SpanAttribute) []attribute.KeyValue {
+	kvs := make([]attribute.KeyValue, 0, len(attrs))
+	for _, a := range attrs {
+		switch v := a.Value.(type) {
+		case string:
+			kvs = append(kvs, attribute.String(a.Key, v))
+		case int:
+			kvs = append(kvs, attribute.Int(a.Key, v))
+		case bool:
+			kvs = append(kvs, attribute.Bool(a.Key, v))
+		default:
+			kvs = append(kvs, attribute.String(a.Key, fmt.Sprint(v)))
+		}
+	}
+	return kvs
+}
@@ -0,0 +1,129 @@
+// Package srljrpcprom provides Prometheus implementation of srljrpc.Metrics registered on a caller-supplied registry.
+//
+// Metrics exposed (namespace srljrpc by default):
+//
+//	requests_total{method,datastore,outcome} - requests sent to the target
+//	request_duration_seconds{method,datastore} - latency histogram
+//	request_bytes_total{method}, response_bytes_total{method} - bytes sent and received
+//	errors_total{method,code} - distribution of apierr codes of failed requests, code is the numeric apierr code
+//
+// Target label is added to all metrics if enabled by WithTargetLabel.
+package srljrpcprom
+
+import (
+	"strconv"
+
+	"github.com/azyablov/srljrpc"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Option is a function type to apply options to the Metrics.
+type Option func(*opts)
+
+type opts struct {
+	namespace string
+	buckets   []float64
+	target    bool
+}
+
+// WithNamespace sets the namespace of the metrics, srljrpc by default.
+func WithNamespace(ns string) Option {
+	return func(o *opts) {
+		o.namespace = ns
+	}
+}
+
+// WithBuckets sets buckets of the latency histogram in seconds, prometheus.DefBuckets by default.
+func WithBuckets(b []float64) Option {
+	return func(o *opts) {
+		o.buckets = b
+	}
+}
+
+// WithTargetLabel adds target label to all metrics, beware of cardinality for large fleets.
+func WithTargetLabel() Option {
+	return func(o *opts) {
+		o.target = true
+	}
+}
+
+// Metrics type to represent Prometheus collectors implementing srljrpc.Metrics.
+type Metrics struct {
+	target   bool
+	requests *prometheus.CounterVec
+	latency  *prometheus.HistogramVec
+	sent     *prometheus.CounterVec
+	received *prometheus.CounterVec
+	errors   *prometheus.CounterVec
+}
+
+// New creates Metrics and registers collectors on the registry, options are applied in order of appearance.
+func New(reg prometheus.Registerer, options ...Option) (*Metrics, error) {
+	o := &opts{namespace: "srljrpc", buckets: prometheus.DefBuckets}
+	for _, opt := range options {
+		opt(o)
+	}
+	labels := func(ls ...string) []string {
+		if o.target {
+			return append([]string{"target"}, ls...)
+		}
+		return ls
+	}
+	m := &Metrics{
+		target: o.target,
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: o.namespace,
+			Name:      "requests_total",
+			Help:      "Total number of JSON RPC requests sent to the target.",
+		}, labels("method", "datastore", "outcome")),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: o.namespace,
+			Name:      "request_duration_seconds",
+			Help:      "Latency of JSON RPC requests in seconds.",
+			Buckets:   o.buckets,
+		}, labels("method", "datastore")),
+		sent: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: o.namespace,
+			Name:      "request_bytes_total",
+			Help:      "Total number of bytes sent in JSON RPC requests.",
+		}, labels("method")),
+		received: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: o.namespace,
+			Name:      "response_bytes_total",
+			Help:      "Total number of bytes received in JSON RPC responses.",
+		}, labels("method")),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: o.namespace,
+			Name:      "errors_total",
+			Help:      "Total number of failed JSON RPC requests by apierr code.",
+		}, labels("method", "code")),
+	}
+	cs := []prometheus.Collector{m.requests, m.latency, m.sent, m.received, m.errors}
+	for i, c := range cs {
+		if err := reg.Register(c); err != nil {
+			// unregistering collectors registered so far, so New could be called again
+			for _, rc := range cs[:i] {
+				reg.Unregister(rc)
+			}
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// ObserveRequest records the outcome of the request, implements srljrpc.Metrics.
+func (m *Metrics) ObserveRequest(o srljrpc.RequestObservation) {
+	labels := func(ls ...string) []string {
+		if m.target {
+			return append([]string{o.Target}, ls...)
+		}
+		return ls
+	}
+	m.requests.WithLabelValues(labels(o.Method, o.Datastore, o.Outcome)...).Inc()
+	m.latency.WithLabelValues(labels(o.Method, o.Datastore)...).Observe(o.Latency.Seconds())
+	m.sent.WithLabelValues(labels(o.Method)...).Add(float64(o.BytesSent))
+	m.received.WithLabelValues(labels(o.Method)...).Add(float64(o.BytesReceived))
+	if o.Outcome != srljrpc.OutcomeSuccess {
+		m.errors.WithLabelValues(labels(o.Method, strconv.Itoa(int(o.Code)))...).Inc()
+	}
+}
@@ -0,0 +1,109 @@
+package srljrpctest
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/azyablov/srljrpc/path"
+)
+
+// TOOLS datastore paths used to manage configuration checkpoints.
+const (
+	checkpointGeneratePath = "/system/configuration/generate-checkpoint"
+	checkpointPath         = "/system/configuration/checkpoint[id=*]"
+)
+
+// checkpoint keeps configuration checkpoint metadata and snapshot of running datastore.
+type checkpoint struct {
+	name     string
+	comment  string
+	username string
+	created  time.Time
+	version  string
+	running  map[string]interface{}
+}
+
+// generateCheckpoint is the default ToolsHandler to generate checkpoint of running datastore, the most recent checkpoint has ID 0.
+// Name and comment are taken from JSON value of the command, e.g. {"name":"initial","comment":"before change"}.
+func generateCheckpoint(s *Server, p string, value interface{}) error {
+	version, _ := getNode(s.state, path.MustParse("/system/information/version"))
+	cp := &checkpoint{
+		username: s.Username,
+		created:  time.Now().UTC(),
+		version:  fmt.Sprint(version),
+		running:  deepCopy(s.running).(map[string]interface{}),
+	}
+	if m, ok := value.(map[string]interface{}); ok {
+		cp.name, _ = m["name"].(string)
+		cp.comment, _ = m["comment"].(string)
+	}
+	s.cps = append([]*checkpoint{cp}, s.cps...)
+	s.syncCheckpointsLocked()
+	return nil
+}
+
+// revertCheckpoint is the default ToolsHandler to revert running datastore to the checkpoint.
+func revertCheckpoint(s *Server, p string, value interface{}) error {
+	id, err := checkpointID(s, p)
+	if err != nil {
+		return err
+	}
+	s.running = deepCopy(s.cps[id].running).(map[string]interface{})
+	return nil
+}
+
+// clearCheckpoint is the default ToolsHandler to remove the checkpoint, IDs of older checkpoints are decremented.
+func clearCheckpoint(s *Server, p string, value interface{}) error {
+	id, err := checkpointID(s, p)
+	if err != nil {
+		return err
+	}
+	s.cps = append(s.cps[:id:id], s.cps[id+1:]...)
+	s.syncCheckpointsLocked()
+	return nil
+}
+
+// Helper function to extract existing checkpoint ID from the path.
+func checkpointID(s *Server, p string) (int, error) {
+	pp, err := parsePath(p)
+	if err != nil || len(pp.Elems) < 3 || len(pp.Elems[2].Keys) == 0 {
+		return 0, fmt.Errorf("Invalid checkpoint path '%s'", p)
+	}
+	id, err := strconv.Atoi(pp.Elems[2].Keys[0].Value)
+	if err != nil || id < 0 || id >= len(s.cps) {
+		return 0, fmt.Errorf("Checkpoint '%s' does not exist", pp.Elems[2].Keys[0].Value)
+	}
+	return id, nil
+}
+
+// Helper method to render checkpoints metadata into state datastore.
+func (s *Server) syncCheckpointsLocked() {
+	p := path.MustParse("/system/configuration")
+	conf, _ := getNode(s.state, p)
+	cm, _ := conf.(map[string]interface{})
+	if cm == nil {
+		cm = map[string]interface{}{}
+	}
+	delete(cm, "checkpoint")
+	var list []interface{}
+	for id, cp := range s.cps {
+		entry := map[string]interface{}{
+			"id":       id,
+			"username": cp.username,
+			"created":  cp.created.Format(time.RFC3339),
+			"version":  cp.version,
+		}
+		if cp.name != "" {
+			entry["name"] = cp.name
+		}
+		if cp.comment != "" {
+			entry["comment"] = cp.comment
+		}
+		list = append(list, entry)
+	}
+	if len(list) != 0 {
+		cm["checkpoint"] = list
+	}
+	setNode(s.state, p, cm, true)
+}
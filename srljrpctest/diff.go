@@ -0,0 +1,97 @@
+package srljrpctest
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// textDiff renders the difference between two trees in the same way as SR Linux does for JSON output format:
+// changed top level branches are rendered as indented JSON with removed lines prefixed by '-' and added lines prefixed by '+'.
+func textDiff(before, after map[string]interface{}) string {
+	set := map[string]bool{}
+	for k := range before {
+		set[k] = true
+	}
+	for k := range after {
+		set[k] = true
+	}
+	var keys []string
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var sb strings.Builder
+	for _, k := range keys {
+		bv, bok := before[k]
+		av, aok := after[k]
+		if bok == aok && reflect.DeepEqual(bv, av) {
+			continue
+		}
+		a := renderBranch(k, bv, bok)
+		b := renderBranch(k, av, aok)
+		for _, l := range diffLines(a, b) {
+			sb.WriteString(l)
+			sb.WriteString("\n")
+		}
+	}
+	return sb.String()
+}
+
+// Helper function to render top level branch as indented JSON lines.
+func renderBranch(k string, v interface{}, ok bool) []string {
+	if !ok {
+		return nil
+	}
+	b, err := json.MarshalIndent(map[string]interface{}{k: v}, "", "  ")
+	if err != nil {
+		return nil
+	}
+	lines := strings.Split(string(b), "\n")
+	// strip enclosing braces
+	return lines[1 : len(lines)-1]
+}
+
+// diffLines provides line based difference using longest common subsequence.
+func diffLines(a, b []string) []string {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+	var out []string
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			out = append(out, " "+a[i])
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			out = append(out, "-"+a[i])
+			i++
+		default:
+			out = append(out, "+"+b[j])
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		out = append(out, "-"+a[i])
+	}
+	for ; j < len(b); j++ {
+		out = append(out, "+"+b[j])
+	}
+	return out
+}
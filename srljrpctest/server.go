@@ -0,0 +1,778 @@
+// Package srljrpctest provides in-process SR Linux JSON RPC server simulator to exercise JSONRPCClient without any devices.
+//
+// Simulator keeps in-memory JSON trees for running, state and tools datastores, while candidate datastore is created
+// from running datastore per request and committed back to running on successful SET.
+// Server speaks the same /jsonrpc protocol over TLS (including batches) and answers get/set/validate/diff/cli methods
+// with result and error shapes following SR Linux implementation.
+package srljrpctest
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/azyablov/srljrpc"
+	"github.com/azyablov/srljrpc/path"
+)
+
+// JSON RPC error codes returned by the simulator.
+const (
+	CodeParseError     = -32700 // invalid JSON was received by the server
+	CodeInvalidRequest = -32600 // JSON sent is not a valid request object
+	CodeMethodNotFound = -32601 // method does not exist
+	CodeInvalidParams  = -32602 // invalid method parameters
+	CodeServerError    = -1     // generic error returned by SR Linux, e.g. invalid path or failed commit
+)
+
+// Default values used by the simulator.
+const (
+	DefaultUsername = "admin"
+	DefaultPassword = "NokiaSrl1!"
+	DefaultHostname = "srl"
+	DefaultVersion  = "v23.3.1-343-gab924f2e64"
+	DefaultChassis  = "7220 IXR-D2"
+)
+
+// CLIHandler is a function type to answer CLI command. Returned value is used as result of the command.
+type CLIHandler func(cmd string, of string) (interface{}, error)
+
+// ToolsHandler is a function type executed for the matching TOOLS datastore path.
+// Server lock is held while handler is executed, so handler must use only provided Server methods suffixed with Locked.
+type ToolsHandler func(s *Server, path string, value interface{}) error
+
+// Option is a function type to apply options to the Server.
+type Option func(*Server)
+
+// Server is SR Linux JSON RPC simulator based on TLS httptest.Server.
+type Server struct {
+	*httptest.Server
+	Host     string // host of the listener to be used by JSONRPCClient
+	Port     int    // port of the listener to be used by JSONRPCClient
+	Username string // username expected in Basic authorization header
+	Password string // password expected in Basic authorization header
+
+	mu        sync.Mutex
+	running   map[string]interface{}
+	state     map[string]interface{}
+	tools     map[string]interface{}
+	cli       map[string]CLIHandler
+	toolsH    map[string]ToolsHandler
+	confirmed *confirmedCommit
+	requests  int
+	faults    []int
+	cps       []*checkpoint // configuration checkpoints, index is checkpoint ID
+	certAuth  bool          // client certificate is requested and accepted instead of Basic authorization
+}
+
+// confirmedCommit keeps the state of commit confirmed in progress.
+type confirmedCommit struct {
+	rollback map[string]interface{}
+	timer    *time.Timer
+}
+
+// WithCredentials sets credentials expected by the server.
+func WithCredentials(u, p string) Option {
+	return func(s *Server) {
+		s.Username = u
+		s.Password = p
+	}
+}
+
+// WithClientCertAuth makes the server to request client certificate, any presented certificate is accepted as authenticated.
+func WithClientCertAuth() Option {
+	return func(s *Server) {
+		s.certAuth = true
+	}
+}
+
+// WithHostname sets the system host-name in running datastore.
+func WithHostname(h string) Option {
+	return func(s *Server) {
+		s.mustSet(s.running, "/system/name/host-name", h)
+	}
+}
+
+// WithVersion sets the system software version in state datastore.
+func WithVersion(v string) Option {
+	return func(s *Server) {
+		s.mustSet(s.state, "/system/information/version", v)
+	}
+}
+
+// WithRunning merges provided JSON tree into running datastore.
+func WithRunning(tree map[string]interface{}) Option {
+	return func(s *Server) {
+		merge(s.running, tree)
+	}
+}
+
+// WithState merges provided JSON tree into state datastore, state only data is not visible in running datastore.
+func WithState(tree map[string]interface{}) Option {
+	return func(s *Server) {
+		merge(s.state, tree)
+	}
+}
+
+// NewServer starts and returns a new TLS Server with default configuration, options are applied in order of appearance.
+// The caller should call Close when finished, to shut it down.
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		Username: DefaultUsername,
+		Password: DefaultPassword,
+		running:  defaultRunning(),
+		state:    defaultState(),
+		tools:    map[string]interface{}{},
+		cli:      map[string]CLIHandler{},
+		toolsH:   map[string]ToolsHandler{},
+	}
+	s.mustSet(s.state, "/system/information/version", DefaultVersion)
+	s.mustSet(s.state, "/system/configuration", map[string]interface{}{})
+	s.HandleCLI("show version", s.showVersion)
+	s.HandleTools("/system/configuration/confirmed-accept", confirmedAccept)
+	s.HandleTools("/system/configuration/confirmed-reject", confirmedReject)
+	s.HandleTools(checkpointGeneratePath, generateCheckpoint)
+	s.HandleTools(checkpointPath+"/revert", revertCheckpoint)
+	s.HandleTools(checkpointPath+"/clear", clearCheckpoint)
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	s.Server = httptest.NewUnstartedServer(http.HandlerFunc(s.serveHTTP))
+	if s.certAuth {
+		s.Server.TLS = &tls.Config{ClientAuth: tls.RequestClientCert}
+	}
+	s.StartTLS()
+	u, _ := url.Parse(s.URL)
+	s.Host = u.Hostname()
+	s.Port, _ = strconv.Atoi(u.Port())
+	return s
+}
+
+// Close shuts down the server and stops pending commit confirmed timer.
+func (s *Server) Close() {
+	s.mu.Lock()
+	if s.confirmed != nil {
+		s.confirmed.timer.Stop()
+		s.confirmed = nil
+	}
+	s.mu.Unlock()
+	s.Server.Close()
+}
+
+// ClientOptions returns ClientOptions to connect JSONRPCClient to the server.
+func (s *Server) ClientOptions() []srljrpc.ClientOption {
+	skipVerify := true
+	return []srljrpc.ClientOption{
+		srljrpc.WithOptPort(&s.Port),
+		srljrpc.WithOptCredentials(&s.Username, &s.Password),
+		srljrpc.WithOptTLS(&srljrpc.TLSAttr{SkipVerify: &skipVerify}),
+	}
+}
+
+// NewClient creates a new JSONRPCClient connected to the server, additional options are applied after ClientOptions.
+func (s *Server) NewClient(opts ...srljrpc.ClientOption) (*srljrpc.JSONRPCClient, error) {
+	return srljrpc.NewJSONRPCClient(&s.Host, append(s.ClientOptions(), opts...)...)
+}
+
+// HandleCLI registers CLI handler for the command. Commands are matched exactly after trimming spaces.
+func (s *Server) HandleCLI(cmd string, h CLIHandler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cli[strings.TrimSpace(cmd)] = h
+}
+
+// HandleTools registers handler for the TOOLS datastore path. Paths are matched without value suffix,
+// key value '*' matches any value, e.g. /system/configuration/checkpoint[id=*]/revert.
+func (s *Server) HandleTools(path string, h ToolsHandler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.toolsH[path] = h
+}
+
+// Get returns JSON tree under the path from specified datastore: running, state or tools.
+func (s *Server) Get(ds string, path string) (interface{}, error) {
+	p, err := parsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	root, err := s.datastoreLocked(ds)
+	if err != nil {
+		return nil, err
+	}
+	return getNode(root, p)
+}
+
+// Set updates value under the path in specified datastore: running, state or tools.
+func (s *Server) Set(ds string, path string, value interface{}) error {
+	p, err := parsePath(path)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	root, err := s.datastoreLocked(ds)
+	if err != nil {
+		return err
+	}
+	return setNode(root, p, value, false)
+}
+
+// FailNext makes the server fail next n requests with HTTP status, zero status closes the connection without response.
+// Used to emulate transient failures, e.g. when device is busy committing.
+func (s *Server) FailNext(n int, status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := 0; i < n; i++ {
+		s.faults = append(s.faults, status)
+	}
+}
+
+// Helper method returning the next injected fault if any.
+func (s *Server) nextFault() (int, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.faults) == 0 {
+		return 0, false
+	}
+	status := s.faults[0]
+	s.faults = s.faults[1:]
+	s.requests++
+	return status, true
+}
+
+// Requests returns number of JSON RPC requests served, including requests failed by FailNext.
+func (s *Server) Requests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests
+}
+
+// CommitConfirmedPending reports whether commit confirmed is in progress.
+func (s *Server) CommitConfirmedPending() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.confirmed != nil
+}
+
+// RunningLocked returns running datastore tree to be used by ToolsHandler.
+func (s *Server) RunningLocked() map[string]interface{} {
+	return s.running
+}
+
+// StateLocked returns state datastore tree to be used by ToolsHandler.
+func (s *Server) StateLocked() map[string]interface{} {
+	return s.state
+}
+
+// ReplaceRunningLocked replaces running datastore tree, to be used by ToolsHandler.
+func (s *Server) ReplaceRunningLocked(tree map[string]interface{}) {
+	s.running = deepCopy(tree).(map[string]interface{})
+}
+
+// Helper method returning datastore tree by name. Server lock must be held.
+func (s *Server) datastoreLocked(ds string) (map[string]interface{}, error) {
+	switch ds {
+	case "running", "candidate", "":
+		return s.running, nil
+	case "state":
+		return s.state, nil
+	case "tools":
+		return s.tools, nil
+	default:
+		return nil, fmt.Errorf("unknown datastore %q", ds)
+	}
+}
+
+// Helper method to set value during server initialization.
+func (s *Server) mustSet(root map[string]interface{}, path string, value interface{}) {
+	p, err := parsePath(path)
+	if err != nil {
+		panic(err)
+	}
+	if err := setNode(root, p, value, false); err != nil {
+		panic(err)
+	}
+}
+
+// rpcRequest represents JSON RPC request decoded by the server for any method.
+type rpcRequest struct {
+	JSONRpcVersion string          `json:"jsonrpc"`
+	ID             *int            `json:"id"`
+	Method         string          `json:"method"`
+	Params         json.RawMessage `json:"params"`
+}
+
+// rpcCommand represents command of get/set/validate/diff method.
+type rpcCommand struct {
+	Path      string          `json:"path"`
+	Value     json.RawMessage `json:"value,omitempty"`
+	Action    string          `json:"action,omitempty"`
+	Datastore string          `json:"datastore,omitempty"`
+}
+
+// rpcParams represents params of get/set/validate/diff method.
+type rpcParams struct {
+	Commands       []rpcCommand `json:"commands"`
+	Datastore      string       `json:"datastore,omitempty"`
+	OutputFormat   string       `json:"output-format,omitempty"`
+	YangModels     string       `json:"yang-models,omitempty"`
+	ConfirmTimeout int          `json:"confirm-timeout,omitempty"`
+}
+
+// cliParams represents params of cli method.
+type cliParams struct {
+	Commands     []string `json:"commands"`
+	OutputFormat string   `json:"output-format,omitempty"`
+}
+
+// rpcError represents JSON RPC error object returned by the server.
+type rpcError struct {
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+// rpcResponse represents JSON RPC response returned by the server.
+type rpcResponse struct {
+	JSONRpcVersion string      `json:"jsonrpc"`
+	ID             *int        `json:"id"`
+	Result         interface{} `json:"result,omitempty"`
+	Error          *rpcError   `json:"error,omitempty"`
+}
+
+// HTTP handler of the simulator.
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/jsonrpc" {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if !s.authorized(r) {
+		w.Header().Set("WWW-Authenticate", `Basic realm="jsonrpc"`)
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	if status, ok := s.nextFault(); ok {
+		if status != 0 {
+			w.WriteHeader(status)
+			return
+		}
+		if hj, ok := w.(http.Hijacker); ok {
+			if conn, _, err := hj.Hijack(); err == nil {
+				conn.Close()
+				return
+			}
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	var raw json.RawMessage
+	if err := json.NewDecoder(r.Body).Decode(&raw); err != nil {
+		json.NewEncoder(w).Encode(rpcResponse{JSONRpcVersion: "2.0", Error: &rpcError{Code: CodeParseError, Message: "Parse error"}})
+		return
+	}
+	if b := bytes.TrimSpace(raw); len(b) == 0 || b[0] != '[' {
+		json.NewEncoder(w).Encode(s.dispatchRaw(raw))
+		return
+	}
+	// batch request is answered with array of responses in order of requests
+	var batch []json.RawMessage
+	if err := json.Unmarshal(raw, &batch); err != nil || len(batch) == 0 {
+		json.NewEncoder(w).Encode(rpcResponse{JSONRpcVersion: "2.0", Error: &rpcError{Code: CodeInvalidRequest, Message: "Invalid Request"}})
+		return
+	}
+	resps := make([]rpcResponse, 0, len(batch))
+	for _, m := range batch {
+		resps = append(resps, s.dispatchRaw(m))
+	}
+	json.NewEncoder(w).Encode(resps)
+}
+
+// Helper method to decode and dispatch a single JSON RPC request.
+func (s *Server) dispatchRaw(raw json.RawMessage) rpcResponse {
+	var req rpcRequest
+	if err := json.Unmarshal(raw, &req); err != nil {
+		return rpcResponse{JSONRpcVersion: "2.0", Error: &rpcError{Code: CodeInvalidRequest, Message: "Invalid Request"}}
+	}
+	return s.dispatch(req)
+}
+
+// Helper method to verify Basic authorization header or client certificate if enabled.
+func (s *Server) authorized(r *http.Request) bool {
+	if s.certAuth && r.TLS != nil && len(r.TLS.PeerCertificates) > 0 {
+		return true
+	}
+	h := r.Header.Get("Authorization")
+	if !strings.HasPrefix(h, "Basic ") {
+		return false
+	}
+	b, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(h, "Basic "))
+	if err != nil {
+		return false
+	}
+	return string(b) == s.Username+":"+s.Password
+}
+
+// dispatch executes JSON RPC request and builds the response.
+func (s *Server) dispatch(req rpcRequest) rpcResponse {
+	resp := rpcResponse{JSONRpcVersion: "2.0", ID: req.ID}
+	if req.JSONRpcVersion != "2.0" || req.ID == nil {
+		resp.Error = &rpcError{Code: CodeInvalidRequest, Message: "Invalid Request"}
+		return resp
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests++
+
+	var result interface{}
+	var err error
+	switch req.Method {
+	case "get", "set", "validate", "diff":
+		var p rpcParams
+		if err := json.Unmarshal(req.Params, &p); err != nil {
+			resp.Error = &rpcError{Code: CodeInvalidParams, Message: "Invalid params"}
+			return resp
+		}
+		switch req.Method {
+		case "get":
+			result, err = s.getLocked(p)
+		case "set":
+			result, err = s.setLocked(p)
+		case "validate":
+			result, err = s.validateLocked(p)
+		case "diff":
+			result, err = s.diffLocked(p)
+		}
+	case "cli":
+		var p cliParams
+		if err := json.Unmarshal(req.Params, &p); err != nil {
+			resp.Error = &rpcError{Code: CodeInvalidParams, Message: "Invalid params"}
+			return resp
+		}
+		result, err = s.cliLocked(p)
+	default:
+		resp.Error = &rpcError{Code: CodeMethodNotFound, Message: fmt.Sprintf("Method '%s' not found", req.Method)}
+		return resp
+	}
+	if err != nil {
+		resp.Error = &rpcError{Code: CodeServerError, Message: err.Error()}
+		return resp
+	}
+	resp.Result = result
+	return resp
+}
+
+// Helper method to identify datastore of the command: params level datastore overrides command level one.
+func commandDatastore(p rpcParams, c rpcCommand) string {
+	if p.Datastore != "" {
+		return p.Datastore
+	}
+	if c.Datastore != "" {
+		return c.Datastore
+	}
+	return "candidate"
+}
+
+// getLocked executes GET method.
+func (s *Server) getLocked(p rpcParams) (interface{}, error) {
+	var result []interface{}
+	for _, c := range p.Commands {
+		pp, err := parsePath(c.Path)
+		if err != nil {
+			return nil, fmt.Errorf("Parse error on path '%s': %v", c.Path, err)
+		}
+		var root map[string]interface{}
+		switch ds := commandDatastore(p, c); ds {
+		case "candidate", "running":
+			root = s.running
+		case "state":
+			root = deepCopy(s.running).(map[string]interface{})
+			merge(root, s.state)
+		default:
+			return nil, fmt.Errorf("Datastore '%s' is not supported for method 'get'", ds)
+		}
+		v, err := getNode(root, pp)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, v)
+	}
+	return result, nil
+}
+
+// Helper function to decode command value: JSON strings are used as is, other JSON values are decoded into the tree.
+func commandValue(c rpcCommand, pp *path.Path) (interface{}, error) {
+	if pp.HasValue {
+		return pp.Value, nil
+	}
+	if len(c.Value) == 0 {
+		return "", nil
+	}
+	var v interface{}
+	if err := json.Unmarshal(c.Value, &v); err != nil {
+		return nil, fmt.Errorf("Invalid value for path '%s'", c.Path)
+	}
+	return v, nil
+}
+
+// applyLocked applies commands to the copy of the tree and returns updated copy.
+func applyLocked(root map[string]interface{}, cmds []rpcCommand) (map[string]interface{}, error) {
+	tree := deepCopy(root).(map[string]interface{})
+	for _, c := range cmds {
+		pp, err := parsePath(c.Path)
+		if err != nil {
+			return nil, fmt.Errorf("Parse error on path '%s': %v", c.Path, err)
+		}
+		switch c.Action {
+		case "delete":
+			deleteNode(tree, pp)
+		case "update", "replace":
+			v, err := commandValue(c, pp)
+			if err != nil {
+				return nil, err
+			}
+			if err := setNode(tree, pp, v, c.Action == "replace"); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("Unsupported action '%s' for path '%s'", c.Action, c.Path)
+		}
+	}
+	return tree, nil
+}
+
+// setLocked executes SET method against candidate or tools datastore.
+func (s *Server) setLocked(p rpcParams) (interface{}, error) {
+	switch p.Datastore {
+	case "tools":
+		return s.toolsLocked(p)
+	case "candidate", "":
+	default:
+		return nil, fmt.Errorf("Datastore '%s' is not supported for method 'set'", p.Datastore)
+	}
+
+	if p.ConfirmTimeout > 0 && s.confirmed != nil {
+		return nil, fmt.Errorf("Failed to commit - commit confirmed is already in progress")
+	}
+	tree, err := applyLocked(s.running, p.Commands)
+	if err != nil {
+		return nil, err
+	}
+	if p.ConfirmTimeout > 0 {
+		cc := &confirmedCommit{rollback: deepCopy(s.running).(map[string]interface{})}
+		cc.timer = time.AfterFunc(time.Duration(p.ConfirmTimeout)*time.Second, func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			if s.confirmed == cc {
+				s.running = cc.rollback
+				s.confirmed = nil
+			}
+		})
+		s.confirmed = cc
+	}
+	s.running = tree
+	return []interface{}{map[string]interface{}{}}, nil
+}
+
+// toolsLocked executes SET method against TOOLS datastore.
+func (s *Server) toolsLocked(p rpcParams) (interface{}, error) {
+	for _, c := range p.Commands {
+		if c.Action != "update" {
+			return nil, fmt.Errorf("Only update action is supported for datastore 'tools'")
+		}
+		pp, err := parsePath(c.Path)
+		if err != nil {
+			return nil, fmt.Errorf("Parse error on path '%s': %v", c.Path, err)
+		}
+		v, err := commandValue(c, pp)
+		if err != nil {
+			return nil, err
+		}
+		if h, ok := s.toolsHandlerLocked(pp); ok {
+			if err := h(s, pp.Base(), v); err != nil {
+				return nil, err
+			}
+		}
+		if err := setNode(s.tools, pp, v, false); err != nil {
+			return nil, err
+		}
+	}
+	return []interface{}{map[string]interface{}{}}, nil
+}
+
+// Helper method to find ToolsHandler for the path: exact match is preferred over the match with wildcard key values.
+func (s *Server) toolsHandlerLocked(pp *path.Path) (ToolsHandler, bool) {
+	if h, ok := s.toolsH[pp.Base()]; ok {
+		return h, true
+	}
+	for hp, h := range s.toolsH {
+		if pattern, err := parsePath(hp); err == nil && matchPattern(pattern, pp) {
+			return h, true
+		}
+	}
+	return nil, false
+}
+
+// validateLocked executes VALIDATE method.
+func (s *Server) validateLocked(p rpcParams) (interface{}, error) {
+	if p.Datastore != "candidate" && p.Datastore != "" {
+		return nil, fmt.Errorf("Datastore '%s' is not supported for method 'validate'", p.Datastore)
+	}
+	if _, err := applyLocked(s.running, p.Commands); err != nil {
+		return nil, err
+	}
+	return []interface{}{map[string]interface{}{}}, nil
+}
+
+// diffLocked executes DIFF method and returns textual difference between running and candidate datastores.
+func (s *Server) diffLocked(p rpcParams) (interface{}, error) {
+	if p.Datastore != "candidate" && p.Datastore != "" {
+		return nil, fmt.Errorf("Datastore '%s' is not supported for method 'diff'", p.Datastore)
+	}
+	tree, err := applyLocked(s.running, p.Commands)
+	if err != nil {
+		return nil, err
+	}
+	return []interface{}{textDiff(s.running, tree)}, nil
+}
+
+// cliLocked executes CLI method.
+func (s *Server) cliLocked(p cliParams) (interface{}, error) {
+	of := p.OutputFormat
+	if of == "" {
+		of = "json"
+	}
+	var result []interface{}
+	for _, cmd := range p.Commands {
+		h, ok := s.cli[strings.TrimSpace(cmd)]
+		if !ok {
+			tokens := strings.Fields(cmd)
+			if len(tokens) == 0 {
+				return nil, fmt.Errorf("Parsing error: empty command")
+			}
+			return nil, fmt.Errorf("Parsing error: Unknown token '%s'. Options are [%s]", tokens[0], strings.Join(s.cliTokensLocked(), ", "))
+		}
+		v, err := h(cmd, of)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, v)
+	}
+	return result, nil
+}
+
+// Helper method to list first tokens of registered CLI commands.
+func (s *Server) cliTokensLocked() []string {
+	set := map[string]bool{}
+	for cmd := range s.cli {
+		set[strings.Fields(cmd)[0]] = true
+	}
+	var tokens []string
+	for t := range set {
+		tokens = append(tokens, t)
+	}
+	sort.Strings(tokens)
+	return tokens
+}
+
+// showVersion is default CLI handler for "show version" command. Server lock is held by the caller.
+func (s *Server) showVersion(cmd string, of string) (interface{}, error) {
+	hostname, _ := getNode(s.running, path.New().Elem("system").Elem("name").Elem("host-name"))
+	version, _ := getNode(s.state, path.New().Elem("system").Elem("information").Elem("version"))
+	if of != "json" {
+		return fmt.Sprintf("--------------------------------------------------------------------------\nHostname             : %v\nSoftware Version     : %v\n--------------------------------------------------------------------------\n", hostname, version), nil
+	}
+	return map[string]interface{}{
+		"basic system info": map[string]interface{}{
+			"Hostname":         hostname,
+			"Software Version": version,
+		},
+	}, nil
+}
+
+// confirmedAccept is the default ToolsHandler to accept commit confirmed in progress.
+func confirmedAccept(s *Server, path string, value interface{}) error {
+	if s.confirmed == nil {
+		return fmt.Errorf("No commit confirmed in progress")
+	}
+	s.confirmed.timer.Stop()
+	s.confirmed = nil
+	return nil
+}
+
+// confirmedReject is the default ToolsHandler to reject commit confirmed in progress and roll back changes immediately.
+func confirmedReject(s *Server, path string, value interface{}) error {
+	if s.confirmed == nil {
+		return fmt.Errorf("No commit confirmed in progress")
+	}
+	s.confirmed.timer.Stop()
+	s.running = s.confirmed.rollback
+	s.confirmed = nil
+	return nil
+}
+
+// defaultState returns default state only data: system information, chassis and licenses.
+func defaultState() map[string]interface{} {
+	booted := time.Now().UTC().Add(-time.Hour).Format(time.RFC3339)
+	return map[string]interface{}{
+		"platform": map[string]interface{}{
+			"chassis": map[string]interface{}{
+				"type":           DefaultChassis,
+				"part-number":    "3HE00000AA",
+				"serial-number":  "SIM0000001",
+				"hw-mac-address": "1A:00:00:00:00:00",
+				"last-booted":    booted,
+			},
+		},
+		"system": map[string]interface{}{
+			"information": map[string]interface{}{
+				"description": "SRLinux-" + DefaultVersion + " " + DefaultChassis + " Copyright (c) 2000-2023 Nokia. Kernel simulator",
+				"last-booted": booted,
+			},
+			"license": []interface{}{},
+		},
+	}
+}
+
+// defaultRunning returns default running configuration of the simulator.
+func defaultRunning() map[string]interface{} {
+	var tree map[string]interface{}
+	err := json.Unmarshal([]byte(`{
+		"interface": [
+			{"name": "ethernet-1/1", "admin-state": "enable", "subinterface": [{"index": 1, "admin-state": "enable"}]},
+			{"name": "mgmt0", "admin-state": "enable", "subinterface": [{"index": 0, "admin-state": "enable"}]},
+			{"name": "system0", "admin-state": "enable"}
+		],
+		"network-instance": [
+			{"name": "default", "type": "default"},
+			{"name": "mgmt", "type": "ip-vrf", "description": "Management network instance", "interface": [{"name": "mgmt0.0"}]}
+		],
+		"system": {
+			"name": {"host-name": "`+DefaultHostname+`"},
+			"json-rpc-server": {"admin-state": "enable", "network-instance": [{"name": "mgmt", "https": {"admin-state": "enable"}}]},
+			"lldp": {"admin-state": "enable"}
+		}
+	}`), &tree)
+	if err != nil {
+		panic(err)
+	}
+	return tree
+}
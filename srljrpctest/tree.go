@@ -0,0 +1,287 @@
+package srljrpctest
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/azyablov/srljrpc/path"
+)
+
+// Helper function parsing JSON RPC command path into elements, keys and value (if provided as path suffix).
+func parsePath(s string) (*path.Path, error) {
+	return path.Parse(s)
+}
+
+// Helper function to match the path against pattern with wildcard ('*') key values.
+func matchPattern(pattern, p *path.Path) bool {
+	if len(pattern.Elems) != len(p.Elems) {
+		return false
+	}
+	for i, pe := range pattern.Elems {
+		e := p.Elems[i]
+		if pe.Name != e.Name || len(pe.Keys) != len(e.Keys) {
+			return false
+		}
+		for j, pk := range pe.Keys {
+			if pk.Name != e.Keys[j].Name || (pk.Value != "*" && pk.Value != e.Keys[j].Value) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Helper function to lookup the child node of the container by name, including module prefixed names.
+func lookupChild(m map[string]interface{}, name string) (string, interface{}, bool) {
+	if v, ok := m[name]; ok {
+		return name, v, true
+	}
+	for k, v := range m {
+		if i := strings.LastIndexByte(k, ':'); i >= 0 && k[i+1:] == name {
+			return k, v, true
+		}
+	}
+	return "", nil, false
+}
+
+// Helper function to check if list entry matches all keys.
+func matchKeys(entry map[string]interface{}, keys []path.Key) bool {
+	for _, k := range keys {
+		v, ok := entry[k.Name]
+		if !ok || fmt.Sprint(v) != k.Value {
+			return false
+		}
+	}
+	return true
+}
+
+// Helper function returning sorted names of the container children, used to build error messages.
+func childNames(m map[string]interface{}) []string {
+	var names []string
+	for k := range m {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// getNode returns the node under the path. Missing list entries are reported as empty container.
+func getNode(root map[string]interface{}, p *path.Path) (interface{}, error) {
+	var node interface{} = root
+	for n, e := range p.Elems {
+		m, ok := node.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("Path not valid - unknown element '%s'", e.Name)
+		}
+		key, child, ok := lookupChild(m, e.Name)
+		if !ok {
+			return nil, fmt.Errorf("Path not valid - unknown element '%s'. Options are [%s]", e.Name, strings.Join(childNames(m), ", "))
+		}
+		if len(e.Keys) == 0 {
+			if _, isList := child.([]interface{}); isList && n == len(p.Elems)-1 {
+				// whole list requested
+				return map[string]interface{}{key: deepCopy(child)}, nil
+			}
+			node = child
+			continue
+		}
+		l, ok := child.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("Path not valid - element '%s' is not a list", e.Name)
+		}
+		var found map[string]interface{}
+		for _, le := range l {
+			if entry, ok := le.(map[string]interface{}); ok && matchKeys(entry, e.Keys) {
+				found = entry
+				break
+			}
+		}
+		if found == nil {
+			return map[string]interface{}{}, nil
+		}
+		if n == len(p.Elems)-1 {
+			// keys are not returned as a part of the list entry
+			out := deepCopy(found).(map[string]interface{})
+			for _, k := range e.Keys {
+				delete(out, k.Name)
+			}
+			return out, nil
+		}
+		node = found
+	}
+	return deepCopy(node), nil
+}
+
+// setNode sets the value under the path, creating missing containers and list entries. Replace flag indicates REPLACE action.
+func setNode(root map[string]interface{}, p *path.Path, value interface{}, replace bool) error {
+	if len(p.Elems) == 0 {
+		vm, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("Invalid value for root - container expected")
+		}
+		if replace {
+			for k := range root {
+				delete(root, k)
+			}
+		}
+		merge(root, vm)
+		return nil
+	}
+	parent := root
+	for n, e := range p.Elems {
+		last := n == len(p.Elems)-1
+		key, child, ok := lookupChild(parent, e.Name)
+		if !ok {
+			key = e.Name
+		}
+		if len(e.Keys) == 0 {
+			if last {
+				cm, isMap := child.(map[string]interface{})
+				vm, vIsMap := value.(map[string]interface{})
+				if isMap && vIsMap && !replace {
+					merge(cm, vm)
+				} else {
+					parent[key] = deepCopy(value)
+				}
+				return nil
+			}
+			cm, isMap := child.(map[string]interface{})
+			if !isMap {
+				cm = map[string]interface{}{}
+				parent[key] = cm
+			}
+			parent = cm
+			continue
+		}
+		l, _ := child.([]interface{})
+		var entry map[string]interface{}
+		for _, le := range l {
+			if em, ok := le.(map[string]interface{}); ok && matchKeys(em, e.Keys) {
+				entry = em
+				break
+			}
+		}
+		if entry == nil || (last && replace) {
+			fresh := map[string]interface{}{}
+			for _, k := range e.Keys {
+				fresh[k.Name] = k.Value
+			}
+			if entry == nil {
+				l = append(l, fresh)
+			} else {
+				for i, le := range l {
+					if em, ok := le.(map[string]interface{}); ok && matchKeys(em, e.Keys) {
+						l[i] = fresh
+					}
+				}
+			}
+			parent[key] = l
+			entry = fresh
+		}
+		if last {
+			vm, ok := value.(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("Invalid value for list entry '%s' - container expected", e.Name)
+			}
+			merge(entry, vm)
+			return nil
+		}
+		parent = entry
+	}
+	return nil
+}
+
+// deleteNode removes the node under the path. Deletion of non existing node is not an error.
+func deleteNode(root map[string]interface{}, p *path.Path) {
+	if len(p.Elems) == 0 {
+		for k := range root {
+			delete(root, k)
+		}
+		return
+	}
+	parent := root
+	for n, e := range p.Elems {
+		last := n == len(p.Elems)-1
+		key, child, ok := lookupChild(parent, e.Name)
+		if !ok {
+			return
+		}
+		if len(e.Keys) == 0 {
+			if last {
+				delete(parent, key)
+				return
+			}
+			cm, ok := child.(map[string]interface{})
+			if !ok {
+				return
+			}
+			parent = cm
+			continue
+		}
+		l, ok := child.([]interface{})
+		if !ok {
+			return
+		}
+		var next map[string]interface{}
+		for i, le := range l {
+			em, ok := le.(map[string]interface{})
+			if !ok || !matchKeys(em, e.Keys) {
+				continue
+			}
+			if last {
+				l = append(l[:i:i], l[i+1:]...)
+				if len(l) == 0 {
+					delete(parent, key)
+				} else {
+					parent[key] = l
+				}
+				return
+			}
+			next = em
+			break
+		}
+		if next == nil {
+			return
+		}
+		parent = next
+	}
+}
+
+// merge deep merges src container into dst container.
+func merge(dst, src map[string]interface{}) {
+	for k, sv := range src {
+		dk, dv, ok := lookupChild(dst, k)
+		if !ok {
+			dst[k] = deepCopy(sv)
+			continue
+		}
+		dm, dIsMap := dv.(map[string]interface{})
+		sm, sIsMap := sv.(map[string]interface{})
+		if dIsMap && sIsMap {
+			merge(dm, sm)
+			continue
+		}
+		dst[dk] = deepCopy(sv)
+	}
+}
+
+// deepCopy provides deep copy of the JSON tree.
+func deepCopy(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(t))
+		for k, cv := range t {
+			m[k] = deepCopy(cv)
+		}
+		return m
+	case []interface{}:
+		l := make([]interface{}, len(t))
+		for i, cv := range t {
+			l[i] = deepCopy(cv)
+		}
+		return l
+	default:
+		return t
+	}
+}
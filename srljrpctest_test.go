@@ -0,0 +1,227 @@
+//go:build unit
+
+package srljrpc_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/azyablov/srljrpc"
+	"github.com/azyablov/srljrpc/actions"
+	"github.com/azyablov/srljrpc/apierr"
+	"github.com/azyablov/srljrpc/formats"
+	"github.com/azyablov/srljrpc/srljrpctest"
+	"github.com/azyablov/srljrpc/yms"
+	"github.com/google/go-cmp/cmp"
+)
+
+// Helper function to start simulator and create client connected to it.
+func helperSimClient(t *testing.T, opts ...srljrpctest.Option) (*srljrpctest.Server, *srljrpc.JSONRPCClient) {
+	t.Helper()
+	s := srljrpctest.NewServer(opts...)
+	t.Cleanup(s.Close)
+	c, err := s.NewClient()
+	if err != nil {
+		t.Fatalf("can't create client: %v", err)
+	}
+	return s, c
+}
+
+// Helper function to unmarshal response result into []interface{}.
+func helperResult(t *testing.T, resp *srljrpc.Response) []interface{} {
+	t.Helper()
+	var res []interface{}
+	if err := json.Unmarshal(resp.Result, &res); err != nil {
+		t.Fatalf("can't unmarshal result: %v", err)
+	}
+	return res
+}
+
+func TestSimTargetVerification(t *testing.T) {
+	_, c := helperSimClient(t, srljrpctest.WithHostname("leaf1"), srljrpctest.WithVersion("v23.3.2-106-g4490a15b16"))
+	if c.GetHostname() != "leaf1" {
+		t.Errorf("expected hostname leaf1, got %s", c.GetHostname())
+	}
+	if c.GetSysVer() != "v23.3.2-106-g4490a15b16" {
+		t.Errorf("expected version v23.3.2-106-g4490a15b16, got %s", c.GetSysVer())
+	}
+
+	// Wrong credentials must fail on target verification.
+	s := srljrpctest.NewServer()
+	defer s.Close()
+	u, p := "admin", "wrong"
+	_, err := s.NewClient(srljrpc.WithOptCredentials(&u, &p))
+	if !errors.Is(err, apierr.ErrClntTargetVerification) {
+		t.Errorf("expected error %v, got %v", apierr.ErrClntTargetVerification, err)
+	}
+}
+
+func TestSimGetSetDelete(t *testing.T) {
+	_, c := helperSimClient(t)
+
+	var testData = []struct {
+		testName string
+		call     func() (*srljrpc.Response, error)
+		expErr   error
+		expRes   []interface{}
+	}{
+		{testName: "Get list entry without keys",
+			call:   func() (*srljrpc.Response, error) { return c.Get(`/network-instance[name="mgmt"]`) },
+			expRes: []interface{}{map[string]interface{}{"type": "ip-vrf", "description": "Management network instance", "interface": []interface{}{map[string]interface{}{"name": "mgmt0.0"}}}}},
+		{testName: "Get invalid path",
+			call:   func() (*srljrpc.Response, error) { return c.Get("/system/json-rpc-server/invalid") },
+			expErr: apierr.ErrClntJSONRPCResp},
+		{testName: "Update leaf",
+			call: func() (*srljrpc.Response, error) {
+				return c.Update(0, srljrpc.PV{Path: "/interface[name=system0]/description", Value: srljrpc.CommandValue("test")})
+			},
+			expRes: []interface{}{map[string]interface{}{}}},
+		{testName: "Get updated leaf",
+			call:   func() (*srljrpc.Response, error) { return c.Get("/interface[name=system0]/description") },
+			expRes: []interface{}{"test"}},
+		{testName: "Replace leaf with value in path",
+			call: func() (*srljrpc.Response, error) {
+				return c.Replace(0, srljrpc.PV{Path: "/interface[name=system0]/description:MAC-VRF 1 + REPLACED w/o underscore", Value: srljrpc.CommandValue("")})
+			},
+			expRes: []interface{}{map[string]interface{}{}}},
+		{testName: "Get replaced leaf from state",
+			call: func() (*srljrpc.Response, error) {
+				return c.State("/interface[name=system0]/description", "/system/information/version")
+			},
+			expRes: []interface{}{"MAC-VRF 1 + REPLACED w/o underscore", srljrpctest.DefaultVersion}},
+		{testName: "Delete leaf",
+			call:   func() (*srljrpc.Response, error) { return c.Delete(0, "/interface[name=system0]/description") },
+			expRes: []interface{}{map[string]interface{}{}}},
+		{testName: "Get deleted leaf",
+			call:   func() (*srljrpc.Response, error) { return c.Get("/interface[name=system0]/description") },
+			expErr: apierr.ErrClntJSONRPCResp},
+		{testName: "Validate",
+			call: func() (*srljrpc.Response, error) {
+				return c.Validate(actions.UPDATE, srljrpc.PV{Path: "/interface[name=mgmt0]/description", Value: srljrpc.CommandValue("MGMT")})
+			},
+			expRes: []interface{}{map[string]interface{}{}}},
+		{testName: "Validate does not change running",
+			call:   func() (*srljrpc.Response, error) { return c.Get("/interface[name=mgmt0]/description") },
+			expErr: apierr.ErrClntJSONRPCResp},
+		{testName: "Tools",
+			call: func() (*srljrpc.Response, error) {
+				return c.Tools(srljrpc.PV{Path: "/interface[name=ethernet-1/1]/ethernet/statistics/clear", Value: srljrpc.CommandValue("")})
+			},
+			expRes: []interface{}{map[string]interface{}{}}},
+		{testName: "Confirm accept without commit confirmed",
+			call: func() (*srljrpc.Response, error) {
+				return c.Tools(srljrpc.PV{Path: "/system/configuration/confirmed-accept", Value: srljrpc.CommandValue("")})
+			},
+			expErr: apierr.ErrClntJSONRPCResp},
+	}
+	for _, td := range testData {
+		t.Run(td.testName, func(t *testing.T) {
+			resp, err := td.call()
+			if td.expErr != nil {
+				if !errors.Is(err, td.expErr) {
+					t.Fatalf("expected error %v, got %v", td.expErr, err)
+				}
+				if resp == nil || resp.Error == nil || resp.Error.Message == "" {
+					t.Fatalf("expected RpcError in response, got %v", resp)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if out := cmp.Diff(td.expRes, helperResult(t, resp)); out != "" {
+				t.Errorf("unexpected result (-want +got):\n%s", out)
+			}
+		})
+	}
+}
+
+func TestSimDiffAndCLI(t *testing.T) {
+	_, c := helperSimClient(t, srljrpctest.WithHostname("leaf1"))
+
+	resp, err := c.BulkDiff(nil, nil, []srljrpc.PV{{Path: "/interface[name=system0]/description:UPDATED", Value: srljrpc.CommandValue("")}}, yms.SRL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	res := helperResult(t, resp)
+	if len(res) != 1 || !strings.Contains(res[0].(string), `+      "description": "UPDATED"`) {
+		t.Errorf("unexpected diff result: %v", res)
+	}
+
+	resp, err = c.CLI([]string{"show version"}, formats.JSON)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	exp := []interface{}{map[string]interface{}{"basic system info": map[string]interface{}{"Hostname": "leaf1", "Software Version": srljrpctest.DefaultVersion}}}
+	if out := cmp.Diff(exp, helperResult(t, resp)); out != "" {
+		t.Errorf("unexpected result (-want +got):\n%s", out)
+	}
+
+	_, err = c.CLI([]string{"shw version"}, formats.TEXT)
+	if !errors.Is(err, apierr.ErrClntJSONRPCResp) {
+		t.Errorf("expected error %v, got %v", apierr.ErrClntJSONRPCResp, err)
+	}
+}
+
+func TestSimBulkSetCallBack(t *testing.T) {
+	s, c := helperSimClient(t)
+	pv := []srljrpc.PV{{Path: "/interface[name=system0]/description", Value: srljrpc.CommandValue("CONFIRMED")}}
+
+	// Rejected by callback, commit confirmed stays pending till timeout expiration.
+	resp, err := c.BulkSetCallBack(nil, nil, pv, yms.SRL, 3, 1, func(req *srljrpc.Request, resp *srljrpc.Response) (bool, error) {
+		return false, nil
+	})
+	if err != nil || resp != nil {
+		t.Fatalf("expected nil response and error, got %v, %v", resp, err)
+	}
+	if !s.CommitConfirmedPending() {
+		t.Fatalf("commit confirmed must be pending")
+	}
+	if _, err := c.Tools(srljrpc.PV{Path: "/system/configuration/confirmed-reject", Value: srljrpc.CommandValue("")}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := s.Get("running", "/interface[name=system0]/description"); err == nil {
+		t.Fatalf("changes must be rolled back")
+	}
+
+	// Confirmed by callback.
+	resp, err = c.BulkSetCallBack(nil, nil, pv, yms.SRL, 3, 1, func(req *srljrpc.Request, resp *srljrpc.Response) (bool, error) {
+		return true, nil
+	})
+	if err != nil || resp == nil {
+		t.Fatalf("expected response and nil error, got %v, %v", resp, err)
+	}
+	if s.CommitConfirmedPending() {
+		t.Fatalf("commit confirmed must be accepted")
+	}
+	v, err := s.Get("running", "/interface[name=system0]/description")
+	if err != nil || v != "CONFIRMED" {
+		t.Fatalf("expected CONFIRMED, got %v, %v", v, err)
+	}
+
+	// Without confirm timeout changes are committed right away.
+	pv = []srljrpc.PV{{Path: "/interface[name=system0]/description", Value: srljrpc.CommandValue("COMMITTED")}}
+	resp, err = c.BulkSetCallBack(nil, nil, pv, yms.SRL, 0, 1, func(req *srljrpc.Request, resp *srljrpc.Response) (bool, error) {
+		return false, nil
+	})
+	if err != nil || resp != nil {
+		t.Fatalf("expected nil response and error, got %v, %v", resp, err)
+	}
+	v, err = s.Get("running", "/interface[name=system0]/description")
+	if err != nil || v != "COMMITTED" {
+		t.Fatalf("expected COMMITTED, got %v, %v", v, err)
+	}
+
+	// Response carrying JSON RPC error is returned along with the error.
+	resp, err = c.BulkSetCallBack(nil, nil, []srljrpc.PV{{Path: "/interface[name=ethernet-1/1]", Value: srljrpc.CommandValue("up")}}, yms.SRL, 3, 1, func(req *srljrpc.Request, resp *srljrpc.Response) (bool, error) {
+		return true, nil
+	})
+	if !errors.Is(err, apierr.ErrClntJSONRPCResp) {
+		t.Fatalf("expected error %v, got %v", apierr.ErrClntJSONRPCResp, err)
+	}
+	if resp == nil || resp.Error == nil {
+		t.Fatalf("expected response with JSON RPC error, got %v", resp)
+	}
+}
@@ -0,0 +1,95 @@
+package srljrpc
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/azyablov/srljrpc/apierr"
+)
+
+// Tracer is the interface implemented by tracing providers, e.g. srljrpcotel.Tracer, to trace every request sent via Do
+// and steps of composite operations. StartSpan must be safe for concurrent use.
+type Tracer interface {
+	// StartSpan starts the span as a child of the span found in ctx, if any, and returns context carrying the new span.
+	StartSpan(ctx context.Context, s SpanStart) (context.Context, Span)
+}
+
+// Span is the interface of the span started by Tracer.
+type Span interface {
+	// SetAttributes adds attributes to the span.
+	SetAttributes(attrs ...SpanAttribute)
+	// End ends the span, recording err if it's not nil.
+	End(err error)
+}
+
+// SpanStart type to represent the span to start.
+// Client is true for spans of requests sent to the target, which are client spans in terms of OpenTelemetry.
+type SpanStart struct {
+	Name       string
+	Client     bool
+	Attributes []SpanAttribute
+}
+
+// SpanAttribute type to represent attribute of the span, Value is string, int or bool.
+type SpanAttribute struct {
+	Key   string
+	Value interface{}
+}
+
+// ClientOption to trace every request via Do and the methods based on it,
+// BulkSetCallBack produces child spans for the set, the wait, the callback and the confirm-accept.
+func WithOptTracer(t Tracer) ClientOption {
+	return func(c *JSONRPCClient) error {
+		if t == nil {
+			return apierr.NewClientError(apierr.CodeClntTracerIsNil, nil)
+		}
+		c.tracer = t
+		return nil
+	}
+}
+
+// noopTracer type to represent Tracer used if tracing isn't enabled.
+type noopTracer struct{}
+
+func (noopTracer) StartSpan(ctx context.Context, _ SpanStart) (context.Context, Span) {
+	return ctx, noopSpan{}
+}
+
+type noopSpan struct{}
+
+func (noopSpan) SetAttributes(...SpanAttribute) {}
+func (noopSpan) End(error)                      {}
+
+// Helper method to start a span of composite operation.
+func (c *JSONRPCClient) startSpan(ctx context.Context, name string) (context.Context, Span) {
+	return c.tracer.StartSpan(ctx, SpanStart{Name: name})
+}
+
+// Helper method to start a client span of the request with target, method, datastore, yang-models, command count and request ID attributes.
+func (c *JSONRPCClient) startRequestSpan(ctx context.Context, r Requester) (context.Context, Span) {
+	ri := describeRequest(r)
+	attrs := []SpanAttribute{
+		{"server.address", *c.target.host},
+		{"rpc.system", "jsonrpc"},
+		{"rpc.method", ri.method},
+		{"rpc.jsonrpc.request_id", strconv.Itoa(r.GetID())},
+		{"srljrpc.commands", ri.commands},
+	}
+	if ri.datastore != "" {
+		attrs = append(attrs, SpanAttribute{"srljrpc.datastore", ri.datastore})
+	}
+	if ri.ym != "" {
+		attrs = append(attrs, SpanAttribute{"srljrpc.yang_models", ri.ym})
+	}
+	return c.tracer.StartSpan(ctx, SpanStart{Name: "srljrpc.Do", Client: true, Attributes: attrs})
+}
+
+// Helper function to end the span recording the error and JSON RPC error of the response if any.
+func endSpan(span Span, resp *Response, err error) {
+	if resp != nil && resp.Error != nil {
+		span.SetAttributes(
+			SpanAttribute{"rpc.jsonrpc.error_code", resp.Error.Code},
+			SpanAttribute{"rpc.jsonrpc.error_message", resp.Error.Message})
+	}
+	span.End(err)
+}
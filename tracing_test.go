@@ -0,0 +1,92 @@
+//go:build unit
+
+package srljrpc_test
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/azyablov/srljrpc"
+	"github.com/azyablov/srljrpc/apierr"
+	"github.com/azyablov/srljrpc/srljrpcotel"
+	"github.com/azyablov/srljrpc/yms"
+	"github.com/google/go-cmp/cmp"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracing(t *testing.T) {
+	s, _ := helperSimClient(t)
+	exp := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exp))
+	c, err := s.NewClient(srljrpc.WithOptTracer(srljrpcotel.New(tp)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Do span with attributes
+	exp.Reset()
+	resp, err := c.Get("/system/name", "/system/lldp")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	spans := exp.GetSpans()
+	if len(spans) != 1 || spans[0].Name != "srljrpc.Do" {
+		t.Fatalf("expected single srljrpc.Do span, got %v", spans)
+	}
+	got := map[attribute.Key]attribute.Value{}
+	for _, a := range spans[0].Attributes {
+		got[a.Key] = a.Value
+	}
+	for k, v := range map[attribute.Key]attribute.Value{
+		"server.address":         attribute.StringValue(s.Host),
+		"rpc.method":             attribute.StringValue("get"),
+		"rpc.jsonrpc.request_id": attribute.StringValue(strconv.Itoa(resp.GetID())),
+		"srljrpc.datastore":      attribute.StringValue("running"),
+		"srljrpc.yang_models":    attribute.StringValue("srl"),
+		"srljrpc.commands":       attribute.IntValue(2),
+	} {
+		if got[k].Emit() != v.Emit() {
+			t.Errorf("expected attribute %s=%s, got %s", k, v.Emit(), got[k].Emit())
+		}
+	}
+
+	// BulkSetCallBack child spans
+	exp.Reset()
+	pv := []srljrpc.PV{{Path: "/interface[name=system0]/description", Value: "TRACED"}}
+	if _, err := c.BulkSetCallBack(nil, nil, pv, yms.SRL, 10, 0, func(req *srljrpc.Request, resp *srljrpc.Response) (bool, error) {
+		return true, nil
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	spans = exp.GetSpans()
+	byID := map[string]string{}
+	for _, sp := range spans {
+		byID[sp.SpanContext.SpanID().String()] = sp.Name
+	}
+	// span name <- parent span name
+	var tree []string
+	for _, sp := range spans {
+		tree = append(tree, sp.Name+" <- "+byID[sp.Parent.SpanID().String()])
+	}
+	sort.Strings(tree)
+	expTree := []string{
+		"srljrpc.BulkSetCallBack <- ",
+		"srljrpc.BulkSetCallBack.callback <- srljrpc.BulkSetCallBack",
+		"srljrpc.BulkSetCallBack.confirm-accept <- srljrpc.BulkSetCallBack",
+		"srljrpc.BulkSetCallBack.set <- srljrpc.BulkSetCallBack",
+		"srljrpc.BulkSetCallBack.wait <- srljrpc.BulkSetCallBack",
+		"srljrpc.Do <- srljrpc.BulkSetCallBack.confirm-accept",
+		"srljrpc.Do <- srljrpc.BulkSetCallBack.set",
+	}
+	if out := cmp.Diff(expTree, tree); out != "" {
+		t.Errorf("unexpected spans (-want +got):\n%s", out)
+	}
+
+	if _, err := s.NewClient(srljrpc.WithOptTracer(nil)); !errors.Is(err, apierr.ErrClntTracerIsNil) {
+		t.Errorf("expected error %v, got %v", apierr.ErrClntTracerIsNil, err)
+	}
+}
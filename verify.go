@@ -0,0 +1,61 @@
+package srljrpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/azyablov/srljrpc/apierr"
+)
+
+// ClientOption to defer target verification until the first request, e.g. to create clients for devices which are still booting.
+// Errors of the deferred verification are returned by the first request and match apierr.ErrClntTargetVerification.
+func WithOptLazyVerification() ClientOption {
+	return func(c *JSONRPCClient) error {
+		c.lazy = true
+		return nil
+	}
+}
+
+// Verify re-runs target verification and updates hostname, system version and release of the target.
+func (c *JSONRPCClient) Verify() error {
+	return c.VerifyContext(context.Background())
+}
+
+// VerifyContext is the same as Verify, but uses the provided context for the request.
+func (c *JSONRPCClient) VerifyContext(ctx context.Context) error {
+	c.verifyMu.Lock()
+	defer c.verifyMu.Unlock()
+	return c.verifyLocked(ctx)
+}
+
+// Helper method to run target verification, the caller must hold verifyMu.
+// The target stays unverified on failure, so the next request retries verification.
+func (c *JSONRPCClient) verifyLocked(ctx context.Context) error {
+	if err := c.targetVerification(ctx); err != nil {
+		c.unverified.Store(true)
+		return apierr.NewClientError(apierr.CodeClntTargetVerification, err)
+	}
+	c.unverified.Store(false)
+	return nil
+}
+
+// Helper method to verify the target before the request if verification was deferred or the connection to the target was lost.
+func (c *JSONRPCClient) ensureVerified(ctx context.Context) error {
+	if !c.unverified.Load() {
+		return nil
+	}
+	c.verifyMu.Lock()
+	defer c.verifyMu.Unlock()
+	// could be verified by the concurrent request meanwhile
+	if !c.unverified.Load() {
+		return nil
+	}
+	return c.verifyLocked(ctx)
+}
+
+// Helper method to mark the target for re-verification after failure to reach it, since the device could be rebooted, upgraded or renamed.
+func (c *JSONRPCClient) reverifyOn(err error) {
+	if errors.Is(err, apierr.ErrClntHTTPSend) {
+		c.unverified.Store(true)
+	}
+}
@@ -0,0 +1,97 @@
+//go:build unit
+
+package srljrpc_test
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/azyablov/srljrpc"
+	"github.com/azyablov/srljrpc/apierr"
+	"github.com/azyablov/srljrpc/srljrpctest"
+)
+
+func TestLazyVerification(t *testing.T) {
+	s := srljrpctest.NewServer()
+	t.Cleanup(s.Close)
+
+	// device is still booting
+	s.FailNext(1, http.StatusServiceUnavailable)
+	if _, err := s.NewClient(); !errors.Is(err, apierr.ErrClntTargetVerification) {
+		t.Fatalf("expected error %v, got %v", apierr.ErrClntTargetVerification, err)
+	}
+	s.FailNext(1, http.StatusServiceUnavailable)
+	before := s.Requests()
+	c, err := s.NewClient(srljrpc.WithOptLazyVerification())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s.Requests() != before || c.GetHostname() != "" || c.GetSysVer() != "" {
+		t.Fatalf("expected no verification on creation, got hostname %q and version %q", c.GetHostname(), c.GetSysVer())
+	}
+	// verification is retried by the next request after failure
+	if _, err := c.Get("/system/name"); !errors.Is(err, apierr.ErrClntTargetVerification) || !errors.Is(err, apierr.ErrClntHTTPStatus) {
+		t.Fatalf("expected error %v, got %v", apierr.ErrClntTargetVerification, err)
+	}
+	if _, err := c.Get("/system/name"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.GetHostname() != srljrpctest.DefaultHostname || c.GetSysVer() != srljrpctest.DefaultVersion {
+		t.Errorf("unexpected hostname %q and version %q", c.GetHostname(), c.GetSysVer())
+	}
+	// failed verification, verification + get
+	if s.Requests()-before != 3 {
+		t.Errorf("expected 3 requests, got %d", s.Requests()-before)
+	}
+}
+
+func TestReverification(t *testing.T) {
+	s, c := helperSimClient(t)
+	f, err := c.Facts()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// device is renamed
+	if err := s.Set("running", "/system/name/host-name", "srl2"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Verify(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.GetHostname() != "srl2" {
+		t.Errorf("expected hostname srl2, got %q", c.GetHostname())
+	}
+	if nf, err := c.Facts(); err != nil || nf == f || nf.Hostname != "srl2" {
+		t.Errorf("expected facts refreshed after rename, got %v, %v", nf, err)
+	}
+
+	// device is upgraded and rebooted
+	if err := s.Set("state", "/system/information/version", "v23.10.1-218-ga3fc1bea5a"); err != nil {
+		t.Fatal(err)
+	}
+	s.FailNext(1, 0)
+	if _, err := c.Get("/system/name"); !errors.Is(err, apierr.ErrClntHTTPSend) {
+		t.Fatalf("expected error %v, got %v", apierr.ErrClntHTTPSend, err)
+	}
+	if c.GetSysVer() != srljrpctest.DefaultVersion {
+		t.Errorf("expected version %s before re-verification, got %s", srljrpctest.DefaultVersion, c.GetSysVer())
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.Get("/system/name"); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			_ = c.GetSysVer()
+		}()
+	}
+	wg.Wait()
+	if v, _ := c.GetRelease(); c.GetSysVer() != "v23.10.1-218-ga3fc1bea5a" || v.Minor != 10 {
+		t.Errorf("expected upgraded version after re-verification, got %s", c.GetSysVer())
+	}
+}
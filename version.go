@@ -0,0 +1,68 @@
+package srljrpc
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/azyablov/srljrpc/apierr"
+)
+
+// Regular expression to parse SR Linux software version, e.g. v23.3.1-343-gab924f2e64.
+var versionRe = regexp.MustCompile(`^v?(\d+)\.(\d+)(?:\.(\d+))?(?:-(\d+))?(?:-(\w+))?$`)
+
+// Version type to represent SR Linux software version, e.g. v23.3.1-343-gab924f2e64.
+// Versions are compared by major, minor, patch and build numbers, while commit isn't taken into account.
+type Version struct {
+	Major  int
+	Minor  int
+	Patch  int
+	Build  int    // build number, 0 if absent
+	Commit string // commit hash suffix, e.g. gab924f2e64
+}
+
+// ParseVersion parses SR Linux software version, leading v, patch, build and commit are optional.
+func ParseVersion(s string) (Version, error) {
+	m := versionRe.FindStringSubmatch(s)
+	if m == nil {
+		return Version{}, apierr.NewClientError(apierr.CodeClntVersionParsing, fmt.Errorf("invalid version %q", s))
+	}
+	var v Version
+	for i, p := range []*int{&v.Major, &v.Minor, &v.Patch, &v.Build} {
+		if m[i+1] == "" {
+			continue
+		}
+		n, err := strconv.Atoi(m[i+1])
+		if err != nil {
+			return Version{}, apierr.NewClientError(apierr.CodeClntVersionParsing, err)
+		}
+		*p = n
+	}
+	v.Commit = m[5]
+	return v, nil
+}
+
+// Compare returns -1, 0 or +1 depending on whether v is lower, equal or greater than o.
+func (v Version) Compare(o Version) int {
+	for _, d := range [][2]int{{v.Major, o.Major}, {v.Minor, o.Minor}, {v.Patch, o.Patch}, {v.Build, o.Build}} {
+		switch {
+		case d[0] < d[1]:
+			return -1
+		case d[0] > d[1]:
+			return 1
+		}
+	}
+	return 0
+}
+
+// String returns the version in SR Linux format.
+func (v Version) String() string {
+	s := fmt.Sprintf("v%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Build != 0 || v.Commit != "" {
+		s += fmt.Sprintf("-%d", v.Build)
+	}
+	if v.Commit != "" {
+		s += "-" + v.Commit
+	}
+	return s
+}